@@ -24,6 +24,7 @@ import (
 	appService "github.com/hashicorp/terraform-provider-azurerm/internal/services/appservice/client"
 	attestation "github.com/hashicorp/terraform-provider-azurerm/internal/services/attestation/client"
 	authorization "github.com/hashicorp/terraform-provider-azurerm/internal/services/authorization/client"
+	automanage "github.com/hashicorp/terraform-provider-azurerm/internal/services/automanage/client"
 	automation "github.com/hashicorp/terraform-provider-azurerm/internal/services/automation/client"
 	azureStackHCI "github.com/hashicorp/terraform-provider-azurerm/internal/services/azurestackhci/client"
 	batch "github.com/hashicorp/terraform-provider-azurerm/internal/services/batch/client"
@@ -145,6 +146,7 @@ type Client struct {
 	AppService            *appService.Client
 	Attestation           *attestation.Client
 	Authorization         *authorization.Client
+	Automanage            *automanage.Client
 	Automation            *automation.Client
 	AzureStackHCI         *azurestackhci_v2022_12_01.Client
 	Batch                 *batch.Client
@@ -268,6 +270,7 @@ func (client *Client) Build(ctx context.Context, o *common.ClientOptions) error
 	client.AppService = appService.NewClient(o)
 	client.Attestation = attestation.NewClient(o)
 	client.Authorization = authorization.NewClient(o)
+	client.Automanage = automanage.NewClient(o)
 	client.Automation = automation.NewClient(o)
 	client.AzureStackHCI = azureStackHCI.NewClient(o)
 	client.Batch = batch.NewClient(o)
@@ -0,0 +1,24 @@
+package client
+
+import (
+	"github.com/Azure/azure-sdk-for-go/services/preview/automanage/mgmt/2021-04-30-preview/automanage" // nolint: staticcheck
+	"github.com/hashicorp/terraform-provider-azurerm/internal/common"
+)
+
+type Client struct {
+	ConfigurationProfileAssignmentsClient *automanage.ConfigurationProfileAssignmentsClient
+	ConfigurationProfilesClient           *automanage.ConfigurationProfilesClient
+}
+
+func NewClient(o *common.ClientOptions) *Client {
+	configurationProfileAssignmentsClient := automanage.NewConfigurationProfileAssignmentsClientWithBaseURI(o.ResourceManagerEndpoint, o.SubscriptionId)
+	o.ConfigureClient(&configurationProfileAssignmentsClient.Client, o.ResourceManagerAuthorizer)
+
+	configurationProfilesClient := automanage.NewConfigurationProfilesClientWithBaseURI(o.ResourceManagerEndpoint, o.SubscriptionId)
+	o.ConfigureClient(&configurationProfilesClient.Client, o.ResourceManagerAuthorizer)
+
+	return &Client{
+		ConfigurationProfileAssignmentsClient: &configurationProfileAssignmentsClient,
+		ConfigurationProfilesClient:           &configurationProfilesClient,
+	}
+}
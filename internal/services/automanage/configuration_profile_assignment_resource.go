@@ -0,0 +1,191 @@
+package automanage
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/services/preview/automanage/mgmt/2021-04-30-preview/automanage" // nolint: staticcheck
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/automanage/parse"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/automanage/validate"
+	computeParse "github.com/hashicorp/terraform-provider-azurerm/internal/services/compute/parse"
+	computeValidate "github.com/hashicorp/terraform-provider-azurerm/internal/services/compute/validate"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/validation"
+	"github.com/hashicorp/terraform-provider-azurerm/utils"
+)
+
+// a Virtual Machine can only have a single Configuration Profile Assignment, which is always named `default`
+const configurationProfileAssignmentName = "default"
+
+var builtInConfigurationProfileIds = []string{
+	"/providers/Microsoft.Automanage/bestPractices/AzureBestPracticesDevTest",
+	"/providers/Microsoft.Automanage/bestPractices/AzureBestPracticesProduction",
+}
+
+type ConfigurationProfileAssignmentResource struct{}
+
+var _ sdk.Resource = ConfigurationProfileAssignmentResource{}
+
+type ConfigurationProfileAssignmentModel struct {
+	VirtualMachineId       string `tfschema:"virtual_machine_id"`
+	ConfigurationProfileId string `tfschema:"configuration_profile_id"`
+	Status                 string `tfschema:"status"`
+}
+
+func (r ConfigurationProfileAssignmentResource) ResourceType() string {
+	return "azurerm_automanage_configuration_profile_assignment"
+}
+
+func (r ConfigurationProfileAssignmentResource) ModelObject() interface{} {
+	return &ConfigurationProfileAssignmentModel{}
+}
+
+func (r ConfigurationProfileAssignmentResource) IDValidationFunc() pluginsdk.SchemaValidateFunc {
+	return validate.VirtualMachineConfigurationProfileAssignmentID
+}
+
+func (r ConfigurationProfileAssignmentResource) Arguments() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"virtual_machine_id": {
+			Type:         pluginsdk.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: computeValidate.VirtualMachineID,
+		},
+
+		"configuration_profile_id": {
+			Type:     pluginsdk.TypeString,
+			Required: true,
+			ForceNew: true,
+			ValidateFunc: validation.Any(
+				validate.ConfigurationProfileID,
+				validation.StringInSlice(builtInConfigurationProfileIds, false),
+			),
+		},
+	}
+}
+
+func (r ConfigurationProfileAssignmentResource) Attributes() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"status": {
+			Type:     pluginsdk.TypeString,
+			Computed: true,
+		},
+	}
+}
+
+func (r ConfigurationProfileAssignmentResource) Create() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 30 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.Automanage.ConfigurationProfileAssignmentsClient
+
+			var model ConfigurationProfileAssignmentModel
+			if err := metadata.Decode(&model); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			virtualMachineId, err := computeParse.VirtualMachineID(model.VirtualMachineId)
+			if err != nil {
+				return err
+			}
+
+			id := parse.NewVirtualMachineConfigurationProfileAssignmentID(virtualMachineId.SubscriptionId, virtualMachineId.ResourceGroup, virtualMachineId.Name, configurationProfileAssignmentName)
+
+			existing, err := client.Get(ctx, id.ResourceGroup, id.ConfigurationProfileAssignmentName, id.VirtualMachineName)
+			if err != nil {
+				if !utils.ResponseWasNotFound(existing.Response) {
+					return fmt.Errorf("checking for the presence of an existing %s: %+v", id, err)
+				}
+			}
+			if !utils.ResponseWasNotFound(existing.Response) {
+				return metadata.ResourceRequiresImport(r.ResourceType(), id)
+			}
+
+			parameters := automanage.ConfigurationProfileAssignment{
+				Properties: &automanage.ConfigurationProfileAssignmentProperties{
+					ConfigurationProfile: utils.String(model.ConfigurationProfileId),
+				},
+			}
+
+			if _, err := client.CreateOrUpdate(ctx, id.ConfigurationProfileAssignmentName, parameters, id.ResourceGroup, id.VirtualMachineName); err != nil {
+				return fmt.Errorf("creating %s: %+v", id, err)
+			}
+
+			metadata.SetID(id)
+			return nil
+		},
+	}
+}
+
+func (r ConfigurationProfileAssignmentResource) Read() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 5 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.Automanage.ConfigurationProfileAssignmentsClient
+
+			id, err := parse.VirtualMachineConfigurationProfileAssignmentID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			resp, err := client.Get(ctx, id.ResourceGroup, id.ConfigurationProfileAssignmentName, id.VirtualMachineName)
+			if err != nil {
+				if utils.ResponseWasNotFound(resp.Response) {
+					return metadata.MarkAsGone(id)
+				}
+
+				return fmt.Errorf("retrieving %s: %+v", *id, err)
+			}
+
+			state := ConfigurationProfileAssignmentModel{
+				VirtualMachineId: computeParse.NewVirtualMachineID(id.SubscriptionId, id.ResourceGroup, id.VirtualMachineName).ID(),
+			}
+
+			if props := resp.Properties; props != nil {
+				if props.ConfigurationProfile != nil {
+					configurationProfileId := *props.ConfigurationProfile
+					if profileId, err := parse.ConfigurationProfileID(configurationProfileId); err == nil {
+						configurationProfileId = profileId.ID()
+					} else {
+						for _, v := range builtInConfigurationProfileIds {
+							if strings.EqualFold(v, configurationProfileId) {
+								configurationProfileId = v
+							}
+						}
+					}
+					state.ConfigurationProfileId = configurationProfileId
+				}
+
+				if props.Status != nil {
+					state.Status = *props.Status
+				}
+			}
+
+			return metadata.Encode(&state)
+		},
+	}
+}
+
+func (r ConfigurationProfileAssignmentResource) Delete() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 30 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.Automanage.ConfigurationProfileAssignmentsClient
+
+			id, err := parse.VirtualMachineConfigurationProfileAssignmentID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			if _, err := client.Delete(ctx, id.ResourceGroup, id.ConfigurationProfileAssignmentName, id.VirtualMachineName); err != nil {
+				return fmt.Errorf("deleting %s: %+v", *id, err)
+			}
+
+			return nil
+		},
+	}
+}
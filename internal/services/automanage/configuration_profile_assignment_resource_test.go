@@ -0,0 +1,168 @@
+package automanage_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance/check"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/automanage/parse"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/utils"
+)
+
+type ConfigurationProfileAssignmentResource struct{}
+
+func TestAccAutomanageConfigurationProfileAssignment_basic(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_automanage_configuration_profile_assignment", "test")
+	r := ConfigurationProfileAssignmentResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func TestAccAutomanageConfigurationProfileAssignment_requiresImport(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_automanage_configuration_profile_assignment", "test")
+	r := ConfigurationProfileAssignmentResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.RequiresImportErrorStep(r.requiresImport),
+	})
+}
+
+func TestAccAutomanageConfigurationProfileAssignment_bestPractices(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_automanage_configuration_profile_assignment", "test")
+	r := ConfigurationProfileAssignmentResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.bestPractices(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func (r ConfigurationProfileAssignmentResource) Exists(ctx context.Context, client *clients.Client, state *pluginsdk.InstanceState) (*bool, error) {
+	id, err := parse.VirtualMachineConfigurationProfileAssignmentID(state.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := client.Automanage.ConfigurationProfileAssignmentsClient.Get(ctx, id.ResourceGroup, id.ConfigurationProfileAssignmentName, id.VirtualMachineName)
+	if err != nil {
+		if utils.ResponseWasNotFound(resp.Response) {
+			return utils.Bool(false), nil
+		}
+		return nil, fmt.Errorf("retrieving %s: %+v", id, err)
+	}
+
+	return utils.Bool(resp.ID != nil), nil
+}
+
+func (r ConfigurationProfileAssignmentResource) template(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_virtual_network" "test" {
+  name                = "acctestvn-%d"
+  address_space       = ["10.0.0.0/16"]
+  location            = azurerm_resource_group.test.location
+  resource_group_name = azurerm_resource_group.test.name
+}
+
+resource "azurerm_subnet" "test" {
+  name                 = "internal"
+  resource_group_name  = azurerm_resource_group.test.name
+  virtual_network_name = azurerm_virtual_network.test.name
+  address_prefixes     = ["10.0.2.0/24"]
+}
+
+resource "azurerm_network_interface" "test" {
+  name                = "acctestnic-%d"
+  location            = azurerm_resource_group.test.location
+  resource_group_name = azurerm_resource_group.test.name
+
+  ip_configuration {
+    name                          = "internal"
+    subnet_id                     = azurerm_subnet.test.id
+    private_ip_address_allocation = "Dynamic"
+  }
+}
+
+resource "azurerm_linux_virtual_machine" "test" {
+  name                            = "acctestVM-%d"
+  resource_group_name             = azurerm_resource_group.test.name
+  location                        = azurerm_resource_group.test.location
+  size                            = "Standard_F2"
+  admin_username                  = "adminuser"
+  admin_password                  = "P@$$w0rd1234!"
+  disable_password_authentication = false
+  network_interface_ids = [
+    azurerm_network_interface.test.id,
+  ]
+
+  os_disk {
+    caching              = "ReadWrite"
+    storage_account_type = "Standard_LRS"
+  }
+
+  source_image_reference {
+    publisher = "Canonical"
+    offer     = "UbuntuServer"
+    sku       = "16.04-LTS"
+    version   = "latest"
+  }
+}
+`, ConfigurationProfileResource{}.basic(data), data.RandomInteger, data.RandomInteger, data.RandomInteger)
+}
+
+func (r ConfigurationProfileAssignmentResource) basic(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_automanage_configuration_profile_assignment" "test" {
+  virtual_machine_id       = azurerm_linux_virtual_machine.test.id
+  configuration_profile_id = azurerm_automanage_configuration_profile.test.id
+}
+`, r.template(data))
+}
+
+func (r ConfigurationProfileAssignmentResource) requiresImport(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_automanage_configuration_profile_assignment" "import" {
+  virtual_machine_id       = azurerm_automanage_configuration_profile_assignment.test.virtual_machine_id
+  configuration_profile_id = azurerm_automanage_configuration_profile_assignment.test.configuration_profile_id
+}
+`, r.basic(data))
+}
+
+func (r ConfigurationProfileAssignmentResource) bestPractices(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_automanage_configuration_profile_assignment" "test" {
+  virtual_machine_id       = azurerm_linux_virtual_machine.test.id
+  configuration_profile_id = "/providers/Microsoft.Automanage/bestPractices/AzureBestPracticesDevTest"
+}
+`, r.template(data))
+}
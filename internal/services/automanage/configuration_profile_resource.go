@@ -0,0 +1,717 @@
+package automanage
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/services/preview/automanage/mgmt/2021-04-30-preview/automanage" // nolint: staticcheck
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonschema"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/location"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/operationalinsights/2020-08-01/workspaces"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/automanage/parse"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/automanage/validate"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tags"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/validation"
+	"github.com/hashicorp/terraform-provider-azurerm/utils"
+)
+
+type ConfigurationProfileResource struct{}
+
+var _ sdk.ResourceWithUpdate = ConfigurationProfileResource{}
+
+type ConfigurationProfileModel struct {
+	Name                                string             `tfschema:"name"`
+	ResourceGroupName                   string             `tfschema:"resource_group_name"`
+	Location                            string             `tfschema:"location"`
+	Antimalware                         []AntimalwareModel `tfschema:"antimalware"`
+	AutomationAccountEnabled            bool               `tfschema:"automation_account_enabled"`
+	AzureMonitorEnabled                 bool               `tfschema:"azure_monitor_enabled"`
+	AzureSecurityBaselineAssignmentType string             `tfschema:"azure_security_baseline_assignment_type"`
+	Backup                              []BackupModel      `tfschema:"backup"`
+	BootDiagnosticsEnabled              bool               `tfschema:"boot_diagnostics_enabled"`
+	ChangeTrackingAndInventoryEnabled   bool               `tfschema:"change_tracking_and_inventory_enabled"`
+	DefenderForCloudEnabled             bool               `tfschema:"defender_for_cloud_enabled"`
+	GuestConfigurationEnabled           bool               `tfschema:"guest_configuration_enabled"`
+	LogAnalyticsEnabled                 bool               `tfschema:"log_analytics_enabled"`
+	LogAnalyticsWorkspaceId             string             `tfschema:"log_analytics_workspace_id"`
+	StatusChangeAlertEnabled            bool               `tfschema:"status_change_alert_enabled"`
+	UpdateManagementEnabled             bool               `tfschema:"update_management_enabled"`
+	Tags                                map[string]string  `tfschema:"tags"`
+}
+
+type AntimalwareModel struct {
+	Exclusions                 []AntimalwareExclusionsModel `tfschema:"exclusions"`
+	RealTimeProtectionEnabled  bool                         `tfschema:"real_time_protection_enabled"`
+	ScheduledScanEnabled       bool                         `tfschema:"scheduled_scan_enabled"`
+	ScheduledScanType          string                       `tfschema:"scheduled_scan_type"`
+	ScheduledScanDay           int                          `tfschema:"scheduled_scan_day"`
+	ScheduledScanTimeInMinutes int                          `tfschema:"scheduled_scan_time_in_minutes"`
+}
+
+type AntimalwareExclusionsModel struct {
+	Extensions string `tfschema:"extensions"`
+	Paths      string `tfschema:"paths"`
+	Processes  string `tfschema:"processes"`
+}
+
+type BackupModel struct {
+	PolicyName                    string                       `tfschema:"policy_name"`
+	TimeZone                      string                       `tfschema:"time_zone"`
+	InstantRpRetentionRangeInDays int                          `tfschema:"instant_rp_retention_range_in_days"`
+	SchedulePolicy                []BackupSchedulePolicyModel  `tfschema:"schedule_policy"`
+	RetentionPolicy               []BackupRetentionPolicyModel `tfschema:"retention_policy"`
+}
+
+type BackupSchedulePolicyModel struct {
+	ScheduleRunFrequency string   `tfschema:"schedule_run_frequency"`
+	ScheduleRunTimes     []string `tfschema:"schedule_run_times"`
+	ScheduleRunDays      []string `tfschema:"schedule_run_days"`
+	SchedulePolicyType   string   `tfschema:"schedule_policy_type"`
+}
+
+type BackupRetentionPolicyModel struct {
+	RetentionPolicyType string                         `tfschema:"retention_policy_type"`
+	DailySchedule       []BackupRetentionScheduleModel `tfschema:"daily_schedule"`
+	WeeklySchedule      []BackupRetentionScheduleModel `tfschema:"weekly_schedule"`
+}
+
+type BackupRetentionScheduleModel struct {
+	RetentionTimes    []string `tfschema:"retention_times"`
+	RetentionDuration int      `tfschema:"retention_duration"`
+}
+
+func (r ConfigurationProfileResource) ResourceType() string {
+	return "azurerm_automanage_configuration_profile"
+}
+
+func (r ConfigurationProfileResource) ModelObject() interface{} {
+	return &ConfigurationProfileModel{}
+}
+
+func (r ConfigurationProfileResource) IDValidationFunc() pluginsdk.SchemaValidateFunc {
+	return validate.ConfigurationProfileID
+}
+
+func (r ConfigurationProfileResource) Arguments() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"name": {
+			Type:         pluginsdk.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: validation.StringIsNotEmpty,
+		},
+
+		"resource_group_name": commonschema.ResourceGroupName(),
+
+		"location": commonschema.Location(),
+
+		"antimalware": {
+			Type:     pluginsdk.TypeList,
+			Optional: true,
+			MaxItems: 1,
+			Elem: &pluginsdk.Resource{
+				Schema: map[string]*pluginsdk.Schema{
+					"exclusions": {
+						Type:     pluginsdk.TypeList,
+						Optional: true,
+						MaxItems: 1,
+						Elem: &pluginsdk.Resource{
+							Schema: map[string]*pluginsdk.Schema{
+								"extensions": {
+									Type:         pluginsdk.TypeString,
+									Optional:     true,
+									ValidateFunc: validation.StringIsNotEmpty,
+								},
+
+								"paths": {
+									Type:         pluginsdk.TypeString,
+									Optional:     true,
+									ValidateFunc: validation.StringIsNotEmpty,
+								},
+
+								"processes": {
+									Type:         pluginsdk.TypeString,
+									Optional:     true,
+									ValidateFunc: validation.StringIsNotEmpty,
+								},
+							},
+						},
+					},
+
+					"real_time_protection_enabled": {
+						Type:     pluginsdk.TypeBool,
+						Optional: true,
+						Default:  false,
+					},
+
+					"scheduled_scan_enabled": {
+						Type:     pluginsdk.TypeBool,
+						Optional: true,
+						Default:  false,
+					},
+
+					"scheduled_scan_type": {
+						Type:     pluginsdk.TypeString,
+						Optional: true,
+						Default:  "Quick",
+						ValidateFunc: validation.StringInSlice([]string{
+							"Quick",
+							"Full",
+						}, false),
+					},
+
+					// 0 is every day, 1-7 are Sunday-Saturday and 8 is never
+					"scheduled_scan_day": {
+						Type:         pluginsdk.TypeInt,
+						Optional:     true,
+						Default:      8,
+						ValidateFunc: validation.IntBetween(0, 8),
+					},
+
+					"scheduled_scan_time_in_minutes": {
+						Type:         pluginsdk.TypeInt,
+						Optional:     true,
+						Default:      0,
+						ValidateFunc: validation.IntBetween(0, 1439),
+					},
+				},
+			},
+		},
+
+		"automation_account_enabled": {
+			Type:     pluginsdk.TypeBool,
+			Optional: true,
+			Default:  false,
+		},
+
+		"azure_monitor_enabled": {
+			Type:     pluginsdk.TypeBool,
+			Optional: true,
+			Default:  false,
+		},
+
+		"azure_security_baseline_assignment_type": {
+			Type:     pluginsdk.TypeString,
+			Optional: true,
+			ValidateFunc: validation.StringInSlice([]string{
+				"ApplyAndAutoCorrect",
+				"ApplyAndMonitor",
+				"Audit",
+				"DeployAndAutoCorrect",
+			}, false),
+		},
+
+		"backup": {
+			Type:     pluginsdk.TypeList,
+			Optional: true,
+			MaxItems: 1,
+			Elem: &pluginsdk.Resource{
+				Schema: map[string]*pluginsdk.Schema{
+					"policy_name": {
+						Type:         pluginsdk.TypeString,
+						Optional:     true,
+						ValidateFunc: validation.StringIsNotEmpty,
+					},
+
+					"time_zone": {
+						Type:         pluginsdk.TypeString,
+						Optional:     true,
+						Default:      "UTC",
+						ValidateFunc: validation.StringIsNotEmpty,
+					},
+
+					"instant_rp_retention_range_in_days": {
+						Type:         pluginsdk.TypeInt,
+						Optional:     true,
+						Default:      5,
+						ValidateFunc: validation.IntBetween(1, 5),
+					},
+
+					"schedule_policy": {
+						Type:     pluginsdk.TypeList,
+						Optional: true,
+						MaxItems: 1,
+						Elem: &pluginsdk.Resource{
+							Schema: map[string]*pluginsdk.Schema{
+								"schedule_run_frequency": {
+									Type:     pluginsdk.TypeString,
+									Optional: true,
+									Default:  "Daily",
+									ValidateFunc: validation.StringInSlice([]string{
+										"Daily",
+										"Weekly",
+									}, false),
+								},
+
+								"schedule_run_times": {
+									Type:     pluginsdk.TypeList,
+									Optional: true,
+									Elem: &pluginsdk.Schema{
+										Type:         pluginsdk.TypeString,
+										ValidateFunc: validation.IsRFC3339Time,
+									},
+								},
+
+								"schedule_run_days": {
+									Type:     pluginsdk.TypeList,
+									Optional: true,
+									Elem: &pluginsdk.Schema{
+										Type:         pluginsdk.TypeString,
+										ValidateFunc: validation.IsDayOfTheWeek(false),
+									},
+								},
+
+								"schedule_policy_type": {
+									Type:         pluginsdk.TypeString,
+									Optional:     true,
+									Default:      "SimpleSchedulePolicy",
+									ValidateFunc: validation.StringIsNotEmpty,
+								},
+							},
+						},
+					},
+
+					"retention_policy": {
+						Type:     pluginsdk.TypeList,
+						Optional: true,
+						MaxItems: 1,
+						Elem: &pluginsdk.Resource{
+							Schema: map[string]*pluginsdk.Schema{
+								"retention_policy_type": {
+									Type:         pluginsdk.TypeString,
+									Optional:     true,
+									Default:      "LongTermRetentionPolicy",
+									ValidateFunc: validation.StringIsNotEmpty,
+								},
+
+								"daily_schedule": configurationProfileBackupRetentionScheduleSchema(),
+
+								"weekly_schedule": configurationProfileBackupRetentionScheduleSchema(),
+							},
+						},
+					},
+				},
+			},
+		},
+
+		"boot_diagnostics_enabled": {
+			Type:     pluginsdk.TypeBool,
+			Optional: true,
+			Default:  false,
+		},
+
+		"change_tracking_and_inventory_enabled": {
+			Type:     pluginsdk.TypeBool,
+			Optional: true,
+			Default:  false,
+		},
+
+		"defender_for_cloud_enabled": {
+			Type:     pluginsdk.TypeBool,
+			Optional: true,
+			Default:  false,
+		},
+
+		"guest_configuration_enabled": {
+			Type:     pluginsdk.TypeBool,
+			Optional: true,
+			Default:  false,
+		},
+
+		"log_analytics_enabled": {
+			Type:     pluginsdk.TypeBool,
+			Optional: true,
+			Default:  false,
+		},
+
+		"log_analytics_workspace_id": {
+			Type:         pluginsdk.TypeString,
+			Optional:     true,
+			ValidateFunc: workspaces.ValidateWorkspaceID,
+			RequiredWith: []string{"log_analytics_enabled"},
+		},
+
+		"status_change_alert_enabled": {
+			Type:     pluginsdk.TypeBool,
+			Optional: true,
+			Default:  false,
+		},
+
+		"update_management_enabled": {
+			Type:     pluginsdk.TypeBool,
+			Optional: true,
+			Default:  false,
+		},
+
+		"tags": tags.Schema(),
+	}
+}
+
+func configurationProfileBackupRetentionScheduleSchema() *pluginsdk.Schema {
+	return &pluginsdk.Schema{
+		Type:     pluginsdk.TypeList,
+		Optional: true,
+		MaxItems: 1,
+		Elem: &pluginsdk.Resource{
+			Schema: map[string]*pluginsdk.Schema{
+				"retention_times": {
+					Type:     pluginsdk.TypeList,
+					Optional: true,
+					Elem: &pluginsdk.Schema{
+						Type:         pluginsdk.TypeString,
+						ValidateFunc: validation.IsRFC3339Time,
+					},
+				},
+
+				"retention_duration": {
+					Type:         pluginsdk.TypeInt,
+					Optional:     true,
+					ValidateFunc: validation.IntBetween(7, 9999),
+				},
+			},
+		},
+	}
+}
+
+func (r ConfigurationProfileResource) Attributes() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{}
+}
+
+func (r ConfigurationProfileResource) Create() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 30 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.Automanage.ConfigurationProfilesClient
+			subscriptionId := metadata.Client.Account.SubscriptionId
+
+			var model ConfigurationProfileModel
+			if err := metadata.Decode(&model); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			id := parse.NewConfigurationProfileID(subscriptionId, model.ResourceGroupName, model.Name)
+
+			existing, err := client.Get(ctx, id.Name, id.ResourceGroup)
+			if err != nil {
+				if !utils.ResponseWasNotFound(existing.Response) {
+					return fmt.Errorf("checking for the presence of an existing %s: %+v", id, err)
+				}
+			}
+			if !utils.ResponseWasNotFound(existing.Response) {
+				return metadata.ResourceRequiresImport(r.ResourceType(), id)
+			}
+
+			parameters := automanage.ConfigurationProfile{
+				Location: utils.String(location.Normalize(model.Location)),
+				Properties: &automanage.ConfigurationProfileProperties{
+					Configuration: expandConfigurationProfileConfiguration(model),
+				},
+				Tags: tags.FromTypedObject(model.Tags),
+			}
+
+			if _, err := client.CreateOrUpdate(ctx, id.Name, id.ResourceGroup, parameters); err != nil {
+				return fmt.Errorf("creating %s: %+v", id, err)
+			}
+
+			metadata.SetID(id)
+			return nil
+		},
+	}
+}
+
+func (r ConfigurationProfileResource) Read() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 5 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.Automanage.ConfigurationProfilesClient
+
+			id, err := parse.ConfigurationProfileID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			resp, err := client.Get(ctx, id.Name, id.ResourceGroup)
+			if err != nil {
+				if utils.ResponseWasNotFound(resp.Response) {
+					return metadata.MarkAsGone(id)
+				}
+
+				return fmt.Errorf("retrieving %s: %+v", *id, err)
+			}
+
+			state := ConfigurationProfileModel{
+				Name:              id.Name,
+				ResourceGroupName: id.ResourceGroup,
+				Location:          location.NormalizeNilable(resp.Location),
+				Tags:              tags.ToTypedObject(resp.Tags),
+			}
+
+			if props := resp.Properties; props != nil {
+				if configuration, ok := props.Configuration.(map[string]interface{}); ok {
+					flattenConfigurationProfileConfiguration(configuration, &state)
+				}
+			}
+
+			return metadata.Encode(&state)
+		},
+	}
+}
+
+func (r ConfigurationProfileResource) Update() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 30 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.Automanage.ConfigurationProfilesClient
+
+			id, err := parse.ConfigurationProfileID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			var model ConfigurationProfileModel
+			if err := metadata.Decode(&model); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			// the configuration is a single document, so the whole Configuration Profile is sent on update
+			parameters := automanage.ConfigurationProfile{
+				Location: utils.String(location.Normalize(model.Location)),
+				Properties: &automanage.ConfigurationProfileProperties{
+					Configuration: expandConfigurationProfileConfiguration(model),
+				},
+				Tags: tags.FromTypedObject(model.Tags),
+			}
+
+			if _, err := client.CreateOrUpdate(ctx, id.Name, id.ResourceGroup, parameters); err != nil {
+				return fmt.Errorf("updating %s: %+v", *id, err)
+			}
+
+			return nil
+		},
+	}
+}
+
+func (r ConfigurationProfileResource) Delete() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 30 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.Automanage.ConfigurationProfilesClient
+
+			id, err := parse.ConfigurationProfileID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			if _, err := client.Delete(ctx, id.ResourceGroup, id.Name); err != nil {
+				return fmt.Errorf("deleting %s: %+v", *id, err)
+			}
+
+			return nil
+		},
+	}
+}
+
+// the Configuration of a Configuration Profile is a flat map of settings, keyed by the path to the setting
+func expandConfigurationProfileConfiguration(input ConfigurationProfileModel) map[string]interface{} {
+	output := map[string]interface{}{
+		"AutomationAccount/Enable":              input.AutomationAccountEnabled,
+		"BootDiagnostics/Enable":                input.BootDiagnosticsEnabled,
+		"ChangeTrackingAndInventory/Enable":     input.ChangeTrackingAndInventoryEnabled,
+		"DefenderForCloud/Enable":               input.DefenderForCloudEnabled,
+		"GuestConfiguration/Enable":             input.GuestConfigurationEnabled,
+		"LogAnalytics/Enable":                   input.LogAnalyticsEnabled,
+		"UpdateManagement/Enable":               input.UpdateManagementEnabled,
+		"VMInsights/Enable":                     input.AzureMonitorEnabled,
+		"Alerts/AutomanageStatusChanges/Enable": input.StatusChangeAlertEnabled,
+	}
+
+	if input.LogAnalyticsWorkspaceId != "" {
+		output["LogAnalytics/Workspace"] = input.LogAnalyticsWorkspaceId
+	}
+
+	output["AzureSecurityBaseline/Enable"] = input.AzureSecurityBaselineAssignmentType != ""
+	if input.AzureSecurityBaselineAssignmentType != "" {
+		output["AzureSecurityBaseline/AssignmentType"] = input.AzureSecurityBaselineAssignmentType
+	}
+
+	output["Antimalware/Enable"] = len(input.Antimalware) > 0
+	if len(input.Antimalware) > 0 {
+		antimalware := input.Antimalware[0]
+		output["Antimalware/EnableRealTimeProtection"] = antimalware.RealTimeProtectionEnabled
+		output["Antimalware/RunScheduledScan"] = antimalware.ScheduledScanEnabled
+		output["Antimalware/ScanType"] = antimalware.ScheduledScanType
+		output["Antimalware/ScanDay"] = antimalware.ScheduledScanDay
+		output["Antimalware/ScanTimeInMinutes"] = antimalware.ScheduledScanTimeInMinutes
+
+		if len(antimalware.Exclusions) > 0 {
+			exclusions := antimalware.Exclusions[0]
+			output["Antimalware/Exclusions/Extensions"] = exclusions.Extensions
+			output["Antimalware/Exclusions/Paths"] = exclusions.Paths
+			output["Antimalware/Exclusions/Processes"] = exclusions.Processes
+		}
+	}
+
+	output["Backup/Enable"] = len(input.Backup) > 0
+	if len(input.Backup) > 0 {
+		backup := input.Backup[0]
+		if backup.PolicyName != "" {
+			output["Backup/PolicyName"] = backup.PolicyName
+		}
+		output["Backup/TimeZone"] = backup.TimeZone
+		output["Backup/InstantRpRetentionRangeInDays"] = backup.InstantRpRetentionRangeInDays
+
+		if len(backup.SchedulePolicy) > 0 {
+			schedulePolicy := backup.SchedulePolicy[0]
+			output["Backup/SchedulePolicy/ScheduleRunFrequency"] = schedulePolicy.ScheduleRunFrequency
+			output["Backup/SchedulePolicy/SchedulePolicyType"] = schedulePolicy.SchedulePolicyType
+			if len(schedulePolicy.ScheduleRunTimes) > 0 {
+				output["Backup/SchedulePolicy/ScheduleRunTimes"] = schedulePolicy.ScheduleRunTimes
+			}
+			if len(schedulePolicy.ScheduleRunDays) > 0 {
+				output["Backup/SchedulePolicy/ScheduleRunDays"] = schedulePolicy.ScheduleRunDays
+			}
+		}
+
+		if len(backup.RetentionPolicy) > 0 {
+			retentionPolicy := backup.RetentionPolicy[0]
+			output["Backup/RetentionPolicy/RetentionPolicyType"] = retentionPolicy.RetentionPolicyType
+
+			if len(retentionPolicy.DailySchedule) > 0 {
+				dailySchedule := retentionPolicy.DailySchedule[0]
+				if len(dailySchedule.RetentionTimes) > 0 {
+					output["Backup/RetentionPolicy/DailySchedule/RetentionTimes"] = dailySchedule.RetentionTimes
+				}
+				output["Backup/RetentionPolicy/DailySchedule/RetentionDuration/Count"] = dailySchedule.RetentionDuration
+				output["Backup/RetentionPolicy/DailySchedule/RetentionDuration/DurationType"] = "Days"
+			}
+
+			if len(retentionPolicy.WeeklySchedule) > 0 {
+				weeklySchedule := retentionPolicy.WeeklySchedule[0]
+				if len(weeklySchedule.RetentionTimes) > 0 {
+					output["Backup/RetentionPolicy/WeeklySchedule/RetentionTimes"] = weeklySchedule.RetentionTimes
+				}
+				output["Backup/RetentionPolicy/WeeklySchedule/RetentionDuration/Count"] = weeklySchedule.RetentionDuration
+				output["Backup/RetentionPolicy/WeeklySchedule/RetentionDuration/DurationType"] = "Weeks"
+			}
+		}
+	}
+
+	return output
+}
+
+func flattenConfigurationProfileConfiguration(input map[string]interface{}, state *ConfigurationProfileModel) {
+	getBool := func(key string) bool {
+		v, ok := input[key].(bool)
+		return ok && v
+	}
+	getString := func(key string) string {
+		v, _ := input[key].(string)
+		return v
+	}
+	// numbers are unmarshalled as float64
+	getInt := func(key string) int {
+		v, _ := input[key].(float64)
+		return int(v)
+	}
+	getStringSlice := func(key string) []string {
+		output := make([]string, 0)
+		if v, ok := input[key].([]interface{}); ok {
+			for _, item := range v {
+				if s, ok := item.(string); ok {
+					output = append(output, s)
+				}
+			}
+		}
+		return output
+	}
+	exists := func(key string) bool {
+		_, ok := input[key]
+		return ok
+	}
+
+	state.AutomationAccountEnabled = getBool("AutomationAccount/Enable")
+	state.AzureMonitorEnabled = getBool("VMInsights/Enable")
+	state.BootDiagnosticsEnabled = getBool("BootDiagnostics/Enable")
+	state.ChangeTrackingAndInventoryEnabled = getBool("ChangeTrackingAndInventory/Enable")
+	state.DefenderForCloudEnabled = getBool("DefenderForCloud/Enable")
+	state.GuestConfigurationEnabled = getBool("GuestConfiguration/Enable")
+	state.LogAnalyticsEnabled = getBool("LogAnalytics/Enable")
+	state.LogAnalyticsWorkspaceId = getString("LogAnalytics/Workspace")
+	state.StatusChangeAlertEnabled = getBool("Alerts/AutomanageStatusChanges/Enable")
+	state.UpdateManagementEnabled = getBool("UpdateManagement/Enable")
+
+	if getBool("AzureSecurityBaseline/Enable") {
+		state.AzureSecurityBaselineAssignmentType = getString("AzureSecurityBaseline/AssignmentType")
+	}
+
+	state.Antimalware = make([]AntimalwareModel, 0)
+	if getBool("Antimalware/Enable") {
+		antimalware := AntimalwareModel{
+			Exclusions:                 make([]AntimalwareExclusionsModel, 0),
+			RealTimeProtectionEnabled:  getBool("Antimalware/EnableRealTimeProtection"),
+			ScheduledScanEnabled:       getBool("Antimalware/RunScheduledScan"),
+			ScheduledScanType:          getString("Antimalware/ScanType"),
+			ScheduledScanDay:           getInt("Antimalware/ScanDay"),
+			ScheduledScanTimeInMinutes: getInt("Antimalware/ScanTimeInMinutes"),
+		}
+
+		if exists("Antimalware/Exclusions/Extensions") || exists("Antimalware/Exclusions/Paths") || exists("Antimalware/Exclusions/Processes") {
+			antimalware.Exclusions = append(antimalware.Exclusions, AntimalwareExclusionsModel{
+				Extensions: getString("Antimalware/Exclusions/Extensions"),
+				Paths:      getString("Antimalware/Exclusions/Paths"),
+				Processes:  getString("Antimalware/Exclusions/Processes"),
+			})
+		}
+
+		state.Antimalware = append(state.Antimalware, antimalware)
+	}
+
+	state.Backup = make([]BackupModel, 0)
+	if getBool("Backup/Enable") {
+		backup := BackupModel{
+			PolicyName:                    getString("Backup/PolicyName"),
+			TimeZone:                      getString("Backup/TimeZone"),
+			InstantRpRetentionRangeInDays: getInt("Backup/InstantRpRetentionRangeInDays"),
+			SchedulePolicy:                make([]BackupSchedulePolicyModel, 0),
+			RetentionPolicy:               make([]BackupRetentionPolicyModel, 0),
+		}
+
+		if exists("Backup/SchedulePolicy/ScheduleRunFrequency") {
+			backup.SchedulePolicy = append(backup.SchedulePolicy, BackupSchedulePolicyModel{
+				ScheduleRunFrequency: getString("Backup/SchedulePolicy/ScheduleRunFrequency"),
+				ScheduleRunTimes:     getStringSlice("Backup/SchedulePolicy/ScheduleRunTimes"),
+				ScheduleRunDays:      getStringSlice("Backup/SchedulePolicy/ScheduleRunDays"),
+				SchedulePolicyType:   getString("Backup/SchedulePolicy/SchedulePolicyType"),
+			})
+		}
+
+		if exists("Backup/RetentionPolicy/RetentionPolicyType") {
+			retentionPolicy := BackupRetentionPolicyModel{
+				RetentionPolicyType: getString("Backup/RetentionPolicy/RetentionPolicyType"),
+				DailySchedule:       make([]BackupRetentionScheduleModel, 0),
+				WeeklySchedule:      make([]BackupRetentionScheduleModel, 0),
+			}
+
+			if exists("Backup/RetentionPolicy/DailySchedule/RetentionDuration/Count") {
+				retentionPolicy.DailySchedule = append(retentionPolicy.DailySchedule, BackupRetentionScheduleModel{
+					RetentionTimes:    getStringSlice("Backup/RetentionPolicy/DailySchedule/RetentionTimes"),
+					RetentionDuration: getInt("Backup/RetentionPolicy/DailySchedule/RetentionDuration/Count"),
+				})
+			}
+
+			if exists("Backup/RetentionPolicy/WeeklySchedule/RetentionDuration/Count") {
+				retentionPolicy.WeeklySchedule = append(retentionPolicy.WeeklySchedule, BackupRetentionScheduleModel{
+					RetentionTimes:    getStringSlice("Backup/RetentionPolicy/WeeklySchedule/RetentionTimes"),
+					RetentionDuration: getInt("Backup/RetentionPolicy/WeeklySchedule/RetentionDuration/Count"),
+				})
+			}
+
+			backup.RetentionPolicy = append(backup.RetentionPolicy, retentionPolicy)
+		}
+
+		state.Backup = append(state.Backup, backup)
+	}
+}
@@ -0,0 +1,220 @@
+package automanage_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance/check"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/automanage/parse"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/utils"
+)
+
+type ConfigurationProfileResource struct{}
+
+func TestAccAutomanageConfigurationProfile_basic(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_automanage_configuration_profile", "test")
+	r := ConfigurationProfileResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func TestAccAutomanageConfigurationProfile_requiresImport(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_automanage_configuration_profile", "test")
+	r := ConfigurationProfileResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.RequiresImportErrorStep(r.requiresImport),
+	})
+}
+
+func TestAccAutomanageConfigurationProfile_complete(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_automanage_configuration_profile", "test")
+	r := ConfigurationProfileResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.complete(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func TestAccAutomanageConfigurationProfile_update(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_automanage_configuration_profile", "test")
+	r := ConfigurationProfileResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(),
+		{
+			Config: r.complete(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(),
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func (r ConfigurationProfileResource) Exists(ctx context.Context, client *clients.Client, state *pluginsdk.InstanceState) (*bool, error) {
+	id, err := parse.ConfigurationProfileID(state.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := client.Automanage.ConfigurationProfilesClient.Get(ctx, id.Name, id.ResourceGroup)
+	if err != nil {
+		if utils.ResponseWasNotFound(resp.Response) {
+			return utils.Bool(false), nil
+		}
+		return nil, fmt.Errorf("retrieving %s: %+v", id, err)
+	}
+
+	return utils.Bool(resp.ID != nil), nil
+}
+
+func (r ConfigurationProfileResource) template(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+provider "azurerm" {
+  features {}
+}
+
+resource "azurerm_resource_group" "test" {
+  name     = "acctestRG-automanage-%d"
+  location = "%s"
+}
+`, data.RandomInteger, data.Locations.Primary)
+}
+
+func (r ConfigurationProfileResource) basic(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_automanage_configuration_profile" "test" {
+  name                = "acctest-amcp-%d"
+  resource_group_name = azurerm_resource_group.test.name
+  location            = azurerm_resource_group.test.location
+}
+`, r.template(data), data.RandomInteger)
+}
+
+func (r ConfigurationProfileResource) requiresImport(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_automanage_configuration_profile" "import" {
+  name                = azurerm_automanage_configuration_profile.test.name
+  resource_group_name = azurerm_automanage_configuration_profile.test.resource_group_name
+  location            = azurerm_automanage_configuration_profile.test.location
+}
+`, r.basic(data))
+}
+
+func (r ConfigurationProfileResource) complete(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_log_analytics_workspace" "test" {
+  name                = "acctestLAW-%d"
+  location            = azurerm_resource_group.test.location
+  resource_group_name = azurerm_resource_group.test.name
+  sku                 = "PerGB2018"
+}
+
+resource "azurerm_automanage_configuration_profile" "test" {
+  name                = "acctest-amcp-%d"
+  resource_group_name = azurerm_resource_group.test.name
+  location            = azurerm_resource_group.test.location
+
+  antimalware {
+    exclusions {
+      extensions = "exe;dll"
+      paths      = "C:\\Windows\\Temp;D:\\Temp"
+      processes  = "svchost.exe;notepad.exe"
+    }
+    real_time_protection_enabled   = true
+    scheduled_scan_enabled         = true
+    scheduled_scan_type            = "Quick"
+    scheduled_scan_day             = 1
+    scheduled_scan_time_in_minutes = 1339
+  }
+
+  azure_security_baseline_assignment_type = "ApplyAndAutoCorrect"
+
+  backup {
+    policy_name                        = "acctest-backup-policy-%d"
+    time_zone                          = "UTC"
+    instant_rp_retention_range_in_days = 2
+
+    schedule_policy {
+      schedule_run_frequency = "Daily"
+      schedule_run_days      = ["Monday", "Tuesday"]
+      schedule_run_times     = ["2023-01-01T12:00:00Z"]
+      schedule_policy_type   = "SimpleSchedulePolicy"
+    }
+
+    retention_policy {
+      retention_policy_type = "LongTermRetentionPolicy"
+
+      daily_schedule {
+        retention_times    = ["2023-01-01T12:00:00Z"]
+        retention_duration = 7
+      }
+
+      weekly_schedule {
+        retention_times    = ["2023-01-01T14:00:00Z"]
+        retention_duration = 10
+      }
+    }
+  }
+
+  automation_account_enabled            = true
+  azure_monitor_enabled                 = true
+  boot_diagnostics_enabled              = true
+  change_tracking_and_inventory_enabled = true
+  defender_for_cloud_enabled            = true
+  guest_configuration_enabled           = true
+  log_analytics_enabled                 = true
+  log_analytics_workspace_id            = azurerm_log_analytics_workspace.test.id
+  status_change_alert_enabled           = true
+  update_management_enabled             = true
+
+  tags = {
+    env = "test"
+  }
+}
+`, r.template(data), data.RandomInteger, data.RandomInteger, data.RandomInteger)
+}
@@ -0,0 +1,75 @@
+package parse
+
+// NOTE: this file is generated via 'go:generate' - manual changes will be overwritten
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/resourceids"
+)
+
+type VirtualMachineConfigurationProfileAssignmentId struct {
+	SubscriptionId                     string
+	ResourceGroup                      string
+	VirtualMachineName                 string
+	ConfigurationProfileAssignmentName string
+}
+
+func NewVirtualMachineConfigurationProfileAssignmentID(subscriptionId, resourceGroup, virtualMachineName, configurationProfileAssignmentName string) VirtualMachineConfigurationProfileAssignmentId {
+	return VirtualMachineConfigurationProfileAssignmentId{
+		SubscriptionId:                     subscriptionId,
+		ResourceGroup:                      resourceGroup,
+		VirtualMachineName:                 virtualMachineName,
+		ConfigurationProfileAssignmentName: configurationProfileAssignmentName,
+	}
+}
+
+func (id VirtualMachineConfigurationProfileAssignmentId) String() string {
+	segments := []string{
+		fmt.Sprintf("Configuration Profile Assignment Name %q", id.ConfigurationProfileAssignmentName),
+		fmt.Sprintf("Virtual Machine Name %q", id.VirtualMachineName),
+		fmt.Sprintf("Resource Group %q", id.ResourceGroup),
+	}
+	segmentsStr := strings.Join(segments, " / ")
+	return fmt.Sprintf("%s: (%s)", "Virtual Machine Configuration Profile Assignment", segmentsStr)
+}
+
+func (id VirtualMachineConfigurationProfileAssignmentId) ID() string {
+	fmtString := "/subscriptions/%s/resourceGroups/%s/providers/Microsoft.Compute/virtualMachines/%s/providers/Microsoft.Automanage/configurationProfileAssignments/%s"
+	return fmt.Sprintf(fmtString, id.SubscriptionId, id.ResourceGroup, id.VirtualMachineName, id.ConfigurationProfileAssignmentName)
+}
+
+// VirtualMachineConfigurationProfileAssignmentID parses a VirtualMachineConfigurationProfileAssignment ID into an VirtualMachineConfigurationProfileAssignmentId struct
+func VirtualMachineConfigurationProfileAssignmentID(input string) (*VirtualMachineConfigurationProfileAssignmentId, error) {
+	id, err := resourceids.ParseAzureResourceID(input)
+	if err != nil {
+		return nil, err
+	}
+
+	resourceId := VirtualMachineConfigurationProfileAssignmentId{
+		SubscriptionId: id.SubscriptionID,
+		ResourceGroup:  id.ResourceGroup,
+	}
+
+	if resourceId.SubscriptionId == "" {
+		return nil, fmt.Errorf("ID was missing the 'subscriptions' element")
+	}
+
+	if resourceId.ResourceGroup == "" {
+		return nil, fmt.Errorf("ID was missing the 'resourceGroups' element")
+	}
+
+	if resourceId.VirtualMachineName, err = id.PopSegment("virtualMachines"); err != nil {
+		return nil, err
+	}
+	if resourceId.ConfigurationProfileAssignmentName, err = id.PopSegment("configurationProfileAssignments"); err != nil {
+		return nil, err
+	}
+
+	if err := id.ValidateNoEmptySegments(input); err != nil {
+		return nil, err
+	}
+
+	return &resourceId, nil
+}
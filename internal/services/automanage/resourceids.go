@@ -0,0 +1,4 @@
+package automanage
+
+//go:generate go run ../../tools/generator-resource-id/main.go -path=./ -name=ConfigurationProfile -id=/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/resGroup1/providers/Microsoft.Automanage/configurationProfiles/profile1
+//go:generate go run ../../tools/generator-resource-id/main.go -path=./ -name=VirtualMachineConfigurationProfileAssignment -id=/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/resGroup1/providers/Microsoft.Compute/virtualMachines/vm1/providers/Microsoft.Automanage/configurationProfileAssignments/default
@@ -0,0 +1,225 @@
+package compute
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonschema"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/location"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/compute/parse"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/compute/validate"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tags"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/utils"
+	"github.com/tombuildsstuff/kermit/sdk/compute/2022-08-01/compute"
+)
+
+type CapacityReservationDataSource struct{}
+
+var _ sdk.DataSource = CapacityReservationDataSource{}
+
+type CapacityReservationDataSourceModel struct {
+	Name                        string                                  `tfschema:"name"`
+	CapacityReservationGroupId  string                                  `tfschema:"capacity_reservation_group_id"`
+	Location                    string                                  `tfschema:"location"`
+	AssociatedVirtualMachineIds []string                                `tfschema:"associated_virtual_machine_ids"`
+	PlatformFaultDomainCount    int                                     `tfschema:"platform_fault_domain_count"`
+	Sku                         []CapacityReservationSkuDataSourceModel `tfschema:"sku"`
+	Tags                        map[string]string                       `tfschema:"tags"`
+	Utilization                 []CapacityReservationUtilizationModel   `tfschema:"utilization"`
+	Zone                        string                                  `tfschema:"zone"`
+}
+
+type CapacityReservationSkuDataSourceModel struct {
+	Name     string `tfschema:"name"`
+	Capacity int    `tfschema:"capacity"`
+}
+
+type CapacityReservationUtilizationModel struct {
+	CurrentCapacity            int      `tfschema:"current_capacity"`
+	AllocatedVirtualMachineIds []string `tfschema:"allocated_virtual_machine_ids"`
+}
+
+func (r CapacityReservationDataSource) ResourceType() string {
+	return "azurerm_capacity_reservation"
+}
+
+func (r CapacityReservationDataSource) ModelObject() interface{} {
+	return &CapacityReservationDataSourceModel{}
+}
+
+func (r CapacityReservationDataSource) Arguments() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"name": {
+			Type:         pluginsdk.TypeString,
+			Required:     true,
+			ValidateFunc: validate.CapacityReservationName(),
+		},
+
+		"capacity_reservation_group_id": {
+			Type:         pluginsdk.TypeString,
+			Required:     true,
+			ValidateFunc: validate.CapacityReservationGroupID,
+		},
+	}
+}
+
+func (r CapacityReservationDataSource) Attributes() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"location": commonschema.LocationComputed(),
+
+		"associated_virtual_machine_ids": {
+			Type:     pluginsdk.TypeList,
+			Computed: true,
+			Elem: &pluginsdk.Schema{
+				Type: pluginsdk.TypeString,
+			},
+		},
+
+		"platform_fault_domain_count": {
+			Type:     pluginsdk.TypeInt,
+			Computed: true,
+		},
+
+		"sku": {
+			Type:     pluginsdk.TypeList,
+			Computed: true,
+			Elem: &pluginsdk.Resource{
+				Schema: map[string]*pluginsdk.Schema{
+					"name": {
+						Type:     pluginsdk.TypeString,
+						Computed: true,
+					},
+
+					"capacity": {
+						Type:     pluginsdk.TypeInt,
+						Computed: true,
+					},
+				},
+			},
+		},
+
+		"tags": tags.SchemaDataSource(),
+
+		"utilization": {
+			Type:     pluginsdk.TypeList,
+			Computed: true,
+			Elem: &pluginsdk.Resource{
+				Schema: map[string]*pluginsdk.Schema{
+					"current_capacity": {
+						Type:     pluginsdk.TypeInt,
+						Computed: true,
+					},
+
+					"allocated_virtual_machine_ids": {
+						Type:     pluginsdk.TypeList,
+						Computed: true,
+						Elem: &pluginsdk.Schema{
+							Type: pluginsdk.TypeString,
+						},
+					},
+				},
+			},
+		},
+
+		"zone": {
+			Type:     pluginsdk.TypeString,
+			Computed: true,
+		},
+	}
+}
+
+func (r CapacityReservationDataSource) Read() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 5 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.Compute.CapacityReservationsClient
+
+			var state CapacityReservationDataSourceModel
+			if err := metadata.Decode(&state); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			groupId, err := parse.CapacityReservationGroupID(state.CapacityReservationGroupId)
+			if err != nil {
+				return err
+			}
+
+			id := parse.NewCapacityReservationID(groupId.SubscriptionId, groupId.ResourceGroup, groupId.Name, state.Name)
+
+			// the utilization of the Capacity Reservation is only returned as a part of the Instance View
+			resp, err := client.Get(ctx, id.ResourceGroup, id.CapacityReservationGroupName, id.Name, compute.CapacityReservationInstanceViewTypesInstanceView)
+			if err != nil {
+				if utils.ResponseWasNotFound(resp.Response) {
+					return fmt.Errorf("%s was not found", id)
+				}
+
+				return fmt.Errorf("retrieving %s: %+v", id, err)
+			}
+
+			state.CapacityReservationGroupId = groupId.ID()
+			state.Location = location.NormalizeNilable(resp.Location)
+			state.Sku = flattenCapacityReservationSkuDataSourceModel(resp.Sku)
+			state.Tags = tags.ToTypedObject(resp.Tags)
+
+			if resp.Zones != nil && len(*resp.Zones) > 0 {
+				state.Zone = (*resp.Zones)[0]
+			}
+
+			state.AssociatedVirtualMachineIds = make([]string, 0)
+			state.Utilization = make([]CapacityReservationUtilizationModel, 0)
+			if props := resp.CapacityReservationProperties; props != nil {
+				state.AssociatedVirtualMachineIds = flattenCapacityReservationVirtualMachineIds(props.VirtualMachinesAssociated)
+
+				if props.PlatformFaultDomainCount != nil {
+					state.PlatformFaultDomainCount = int(*props.PlatformFaultDomainCount)
+				}
+
+				if instanceView := props.InstanceView; instanceView != nil && instanceView.UtilizationInfo != nil {
+					utilization := CapacityReservationUtilizationModel{
+						AllocatedVirtualMachineIds: flattenCapacityReservationVirtualMachineIds(instanceView.UtilizationInfo.VirtualMachinesAllocated),
+					}
+					if instanceView.UtilizationInfo.CurrentCapacity != nil {
+						utilization.CurrentCapacity = int(*instanceView.UtilizationInfo.CurrentCapacity)
+					}
+					state.Utilization = append(state.Utilization, utilization)
+				}
+			}
+
+			metadata.SetID(id)
+			return metadata.Encode(&state)
+		},
+	}
+}
+
+func flattenCapacityReservationSkuDataSourceModel(input *compute.Sku) []CapacityReservationSkuDataSourceModel {
+	if input == nil {
+		return []CapacityReservationSkuDataSourceModel{}
+	}
+
+	output := CapacityReservationSkuDataSourceModel{
+		Name: utils.NormalizeNilableString(input.Name),
+	}
+	if input.Capacity != nil {
+		output.Capacity = int(*input.Capacity)
+	}
+
+	return []CapacityReservationSkuDataSourceModel{output}
+}
+
+func flattenCapacityReservationVirtualMachineIds(input *[]compute.SubResourceReadOnly) []string {
+	output := make([]string, 0)
+	if input == nil {
+		return output
+	}
+
+	for _, v := range *input {
+		if v.ID != nil {
+			output = append(output, *v.ID)
+		}
+	}
+
+	return output
+}
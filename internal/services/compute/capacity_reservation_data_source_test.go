@@ -0,0 +1,72 @@
+package compute_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance/check"
+)
+
+type CapacityReservationDataSource struct{}
+
+func TestAccCapacityReservationDataSource_basic(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.azurerm_capacity_reservation", "test")
+	d := CapacityReservationDataSource{}
+
+	data.DataSourceTest(t, []acceptance.TestStep{
+		{
+			Config: d.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("location").Exists(),
+				check.That(data.ResourceName).Key("sku.#").HasValue("1"),
+				check.That(data.ResourceName).Key("sku.0.name").HasValue("Standard_F2"),
+				check.That(data.ResourceName).Key("sku.0.capacity").HasValue("2"),
+				check.That(data.ResourceName).Key("utilization.#").HasValue("1"),
+				check.That(data.ResourceName).Key("utilization.0.allocated_virtual_machine_ids.#").HasValue("0"),
+			),
+		},
+	})
+}
+
+func TestAccCapacityReservationDataSource_virtualMachine(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.azurerm_capacity_reservation", "test")
+	d := CapacityReservationDataSource{}
+
+	data.DataSourceTest(t, []acceptance.TestStep{
+		{
+			Config: d.virtualMachine(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("associated_virtual_machine_ids.#").HasValue("1"),
+				check.That(data.ResourceName).Key("utilization.0.current_capacity").HasValue("1"),
+				check.That(data.ResourceName).Key("utilization.0.allocated_virtual_machine_ids.#").HasValue("1"),
+			),
+		},
+	})
+}
+
+func (CapacityReservationDataSource) basic(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+data "azurerm_capacity_reservation" "test" {
+  name                          = azurerm_capacity_reservation.test.name
+  capacity_reservation_group_id = azurerm_capacity_reservation.test.capacity_reservation_group_id
+}
+`, CapacityReservationResource{}.basic(data))
+}
+
+func (CapacityReservationDataSource) virtualMachine(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+data "azurerm_capacity_reservation" "test" {
+  name                          = azurerm_capacity_reservation.test.name
+  capacity_reservation_group_id = azurerm_capacity_reservation.test.capacity_reservation_group_id
+
+  depends_on = [
+    azurerm_linux_virtual_machine.test,
+  ]
+}
+`, LinuxVirtualMachineResource{}.scalingCapacityReservationGroup(data))
+}
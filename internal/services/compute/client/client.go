@@ -1,7 +1,8 @@
 package client
 
 import (
-	"github.com/Azure/azure-sdk-for-go/services/marketplaceordering/mgmt/2015-06-01/marketplaceordering" // nolint: staticcheck
+	"github.com/Azure/azure-sdk-for-go/services/marketplaceordering/mgmt/2015-06-01/marketplaceordering"               // nolint: staticcheck
+	"github.com/Azure/azure-sdk-for-go/services/virtualmachineimagebuilder/mgmt/2021-10-01/virtualmachineimagebuilder" // nolint: staticcheck
 	"github.com/hashicorp/go-azure-sdk/resource-manager/compute/2021-07-01/skus"
 	"github.com/hashicorp/go-azure-sdk/resource-manager/compute/2021-11-01/availabilitysets"
 	"github.com/hashicorp/go-azure-sdk/resource-manager/compute/2021-11-01/dedicatedhostgroups"
@@ -17,35 +18,44 @@ import (
 )
 
 type Client struct {
-	AvailabilitySetsClient           *availabilitysets.AvailabilitySetsClient
-	CapacityReservationsClient       *compute.CapacityReservationsClient
-	CapacityReservationGroupsClient  *compute.CapacityReservationGroupsClient
-	DedicatedHostsClient             *dedicatedhosts.DedicatedHostsClient
-	DedicatedHostGroupsClient        *dedicatedhostgroups.DedicatedHostGroupsClient
-	DisksClient                      *disks.DisksClient
-	DiskAccessClient                 *compute.DiskAccessesClient
-	DiskEncryptionSetsClient         *diskencryptionsets.DiskEncryptionSetsClient
-	GalleriesClient                  *compute.GalleriesClient
-	GalleryApplicationsClient        *compute.GalleryApplicationsClient
-	GalleryApplicationVersionsClient *compute.GalleryApplicationVersionsClient
-	GalleryImagesClient              *compute.GalleryImagesClient
-	GalleryImageVersionsClient       *compute.GalleryImageVersionsClient
-	ImagesClient                     *compute.ImagesClient
-	MarketplaceAgreementsClient      *marketplaceordering.MarketplaceAgreementsClient
-	ProximityPlacementGroupsClient   *proximityplacementgroups.ProximityPlacementGroupsClient
-	SkusClient                       *skus.SkusClient
-	SSHPublicKeysClient              *sshpublickeys.SshPublicKeysClient
-	SnapshotsClient                  *snapshots.SnapshotsClient
-	UsageClient                      *compute.UsageClient
-	VirtualMachinesClient            *virtualmachines.VirtualMachinesClient
-	VMExtensionImageClient           *compute.VirtualMachineExtensionImagesClient
-	VMExtensionClient                *compute.VirtualMachineExtensionsClient
-	VMScaleSetClient                 *compute.VirtualMachineScaleSetsClient
-	VMScaleSetExtensionsClient       *compute.VirtualMachineScaleSetExtensionsClient
-	VMScaleSetRollingUpgradesClient  *compute.VirtualMachineScaleSetRollingUpgradesClient
-	VMScaleSetVMsClient              *compute.VirtualMachineScaleSetVMsClient
-	VMClient                         *compute.VirtualMachinesClient
-	VMImageClient                    *compute.VirtualMachineImagesClient
+	AvailabilitySetsClient              *availabilitysets.AvailabilitySetsClient
+	CapacityReservationsClient          *compute.CapacityReservationsClient
+	CapacityReservationGroupsClient     *compute.CapacityReservationGroupsClient
+	CommunityGalleryImagesClient        *compute.CommunityGalleryImagesClient
+	CommunityGalleryImageVersionsClient *compute.CommunityGalleryImageVersionsClient
+	DedicatedHostsClient                *dedicatedhosts.DedicatedHostsClient
+	DedicatedHostGroupsClient           *dedicatedhostgroups.DedicatedHostGroupsClient
+	DisksClient                         *disks.DisksClient
+	DiskAccessClient                    *compute.DiskAccessesClient
+	DiskEncryptionSetsClient            *diskencryptionsets.DiskEncryptionSetsClient
+	GalleriesClient                     *compute.GalleriesClient
+	GalleryApplicationsClient           *compute.GalleryApplicationsClient
+	GalleryApplicationVersionsClient    *compute.GalleryApplicationVersionsClient
+	GalleryImagesClient                 *compute.GalleryImagesClient
+	GalleryImageVersionsClient          *compute.GalleryImageVersionsClient
+	GallerySharingProfileClient         *compute.GallerySharingProfileClient
+	ImagesClient                        *compute.ImagesClient
+	ImageTemplatesClient                *virtualmachineimagebuilder.VirtualMachineImageTemplatesClient
+	MarketplaceAgreementsClient         *marketplaceordering.MarketplaceAgreementsClient
+	ProximityPlacementGroupsClient      *proximityplacementgroups.ProximityPlacementGroupsClient
+	RestorePointCollectionsClient       *compute.RestorePointCollectionsClient
+	RestorePointsClient                 *compute.RestorePointsClient
+	SharedGalleryImagesClient           *compute.SharedGalleryImagesClient
+	SharedGalleryImageVersionsClient    *compute.SharedGalleryImageVersionsClient
+	SkusClient                          *skus.SkusClient
+	SSHPublicKeysClient                 *sshpublickeys.SshPublicKeysClient
+	SnapshotsClient                     *snapshots.SnapshotsClient
+	UsageClient                         *compute.UsageClient
+	VirtualMachinesClient               *virtualmachines.VirtualMachinesClient
+	VirtualMachineRunCommandsClient     *compute.VirtualMachineRunCommandsClient
+	VMExtensionImageClient              *compute.VirtualMachineExtensionImagesClient
+	VMExtensionClient                   *compute.VirtualMachineExtensionsClient
+	VMScaleSetClient                    *compute.VirtualMachineScaleSetsClient
+	VMScaleSetExtensionsClient          *compute.VirtualMachineScaleSetExtensionsClient
+	VMScaleSetRollingUpgradesClient     *compute.VirtualMachineScaleSetRollingUpgradesClient
+	VMScaleSetVMsClient                 *compute.VirtualMachineScaleSetVMsClient
+	VMClient                            *compute.VirtualMachinesClient
+	VMImageClient                       *compute.VirtualMachineImagesClient
 }
 
 func NewClient(o *common.ClientOptions) *Client {
@@ -58,6 +68,12 @@ func NewClient(o *common.ClientOptions) *Client {
 	capacityReservationGroupsClient := compute.NewCapacityReservationGroupsClientWithBaseURI(o.ResourceManagerEndpoint, o.SubscriptionId)
 	o.ConfigureClient(&capacityReservationGroupsClient.Client, o.ResourceManagerAuthorizer)
 
+	communityGalleryImagesClient := compute.NewCommunityGalleryImagesClientWithBaseURI(o.ResourceManagerEndpoint, o.SubscriptionId)
+	o.ConfigureClient(&communityGalleryImagesClient.Client, o.ResourceManagerAuthorizer)
+
+	communityGalleryImageVersionsClient := compute.NewCommunityGalleryImageVersionsClientWithBaseURI(o.ResourceManagerEndpoint, o.SubscriptionId)
+	o.ConfigureClient(&communityGalleryImageVersionsClient.Client, o.ResourceManagerAuthorizer)
+
 	dedicatedHostsClient := dedicatedhosts.NewDedicatedHostsClientWithBaseURI(o.ResourceManagerEndpoint)
 	o.ConfigureClient(&dedicatedHostsClient.Client, o.ResourceManagerAuthorizer)
 
@@ -88,15 +104,33 @@ func NewClient(o *common.ClientOptions) *Client {
 	galleryImageVersionsClient := compute.NewGalleryImageVersionsClientWithBaseURI(o.ResourceManagerEndpoint, o.SubscriptionId)
 	o.ConfigureClient(&galleryImageVersionsClient.Client, o.ResourceManagerAuthorizer)
 
+	gallerySharingProfileClient := compute.NewGallerySharingProfileClientWithBaseURI(o.ResourceManagerEndpoint, o.SubscriptionId)
+	o.ConfigureClient(&gallerySharingProfileClient.Client, o.ResourceManagerAuthorizer)
+
 	imagesClient := compute.NewImagesClientWithBaseURI(o.ResourceManagerEndpoint, o.SubscriptionId)
 	o.ConfigureClient(&imagesClient.Client, o.ResourceManagerAuthorizer)
 
+	imageTemplatesClient := virtualmachineimagebuilder.NewVirtualMachineImageTemplatesClientWithBaseURI(o.ResourceManagerEndpoint, o.SubscriptionId)
+	o.ConfigureClient(&imageTemplatesClient.Client, o.ResourceManagerAuthorizer)
+
 	marketplaceAgreementsClient := marketplaceordering.NewMarketplaceAgreementsClientWithBaseURI(o.ResourceManagerEndpoint, o.SubscriptionId)
 	o.ConfigureClient(&marketplaceAgreementsClient.Client, o.ResourceManagerAuthorizer)
 
 	proximityPlacementGroupsClient := proximityplacementgroups.NewProximityPlacementGroupsClientWithBaseURI(o.ResourceManagerEndpoint)
 	o.ConfigureClient(&proximityPlacementGroupsClient.Client, o.ResourceManagerAuthorizer)
 
+	restorePointCollectionsClient := compute.NewRestorePointCollectionsClientWithBaseURI(o.ResourceManagerEndpoint, o.SubscriptionId)
+	o.ConfigureClient(&restorePointCollectionsClient.Client, o.ResourceManagerAuthorizer)
+
+	restorePointsClient := compute.NewRestorePointsClientWithBaseURI(o.ResourceManagerEndpoint, o.SubscriptionId)
+	o.ConfigureClient(&restorePointsClient.Client, o.ResourceManagerAuthorizer)
+
+	sharedGalleryImagesClient := compute.NewSharedGalleryImagesClientWithBaseURI(o.ResourceManagerEndpoint, o.SubscriptionId)
+	o.ConfigureClient(&sharedGalleryImagesClient.Client, o.ResourceManagerAuthorizer)
+
+	sharedGalleryImageVersionsClient := compute.NewSharedGalleryImageVersionsClientWithBaseURI(o.ResourceManagerEndpoint, o.SubscriptionId)
+	o.ConfigureClient(&sharedGalleryImageVersionsClient.Client, o.ResourceManagerAuthorizer)
+
 	skusClient := skus.NewSkusClientWithBaseURI(o.ResourceManagerEndpoint)
 	o.ConfigureClient(&skusClient.Client, o.ResourceManagerAuthorizer)
 
@@ -112,6 +146,9 @@ func NewClient(o *common.ClientOptions) *Client {
 	virtualMachinesClient := virtualmachines.NewVirtualMachinesClientWithBaseURI(o.ResourceManagerEndpoint)
 	o.ConfigureClient(&virtualMachinesClient.Client, o.ResourceManagerAuthorizer)
 
+	virtualMachineRunCommandsClient := compute.NewVirtualMachineRunCommandsClientWithBaseURI(o.ResourceManagerEndpoint, o.SubscriptionId)
+	o.ConfigureClient(&virtualMachineRunCommandsClient.Client, o.ResourceManagerAuthorizer)
+
 	vmExtensionImageClient := compute.NewVirtualMachineExtensionImagesClientWithBaseURI(o.ResourceManagerEndpoint, o.SubscriptionId)
 	o.ConfigureClient(&vmExtensionImageClient.Client, o.ResourceManagerAuthorizer)
 
@@ -137,34 +174,43 @@ func NewClient(o *common.ClientOptions) *Client {
 	o.ConfigureClient(&vmClient.Client, o.ResourceManagerAuthorizer)
 
 	return &Client{
-		AvailabilitySetsClient:           &availabilitySetsClient,
-		CapacityReservationsClient:       &capacityReservationsClient,
-		CapacityReservationGroupsClient:  &capacityReservationGroupsClient,
-		DedicatedHostsClient:             &dedicatedHostsClient,
-		DedicatedHostGroupsClient:        &dedicatedHostGroupsClient,
-		DisksClient:                      &disksClient,
-		DiskAccessClient:                 &diskAccessClient,
-		DiskEncryptionSetsClient:         &diskEncryptionSetsClient,
-		GalleriesClient:                  &galleriesClient,
-		GalleryApplicationsClient:        &galleryApplicationsClient,
-		GalleryApplicationVersionsClient: &galleryApplicationVersionsClient,
-		GalleryImagesClient:              &galleryImagesClient,
-		GalleryImageVersionsClient:       &galleryImageVersionsClient,
-		ImagesClient:                     &imagesClient,
-		MarketplaceAgreementsClient:      &marketplaceAgreementsClient,
-		ProximityPlacementGroupsClient:   &proximityPlacementGroupsClient,
-		SkusClient:                       &skusClient,
-		SSHPublicKeysClient:              &sshPublicKeysClient,
-		SnapshotsClient:                  &snapshotsClient,
-		UsageClient:                      &usageClient,
-		VirtualMachinesClient:            &virtualMachinesClient,
-		VMExtensionImageClient:           &vmExtensionImageClient,
-		VMExtensionClient:                &vmExtensionClient,
-		VMScaleSetClient:                 &vmScaleSetClient,
-		VMScaleSetExtensionsClient:       &vmScaleSetExtensionsClient,
-		VMScaleSetRollingUpgradesClient:  &vmScaleSetRollingUpgradesClient,
-		VMScaleSetVMsClient:              &vmScaleSetVMsClient,
-		VMClient:                         &vmClient,
-		VMImageClient:                    &vmImageClient,
+		AvailabilitySetsClient:              &availabilitySetsClient,
+		CapacityReservationsClient:          &capacityReservationsClient,
+		CapacityReservationGroupsClient:     &capacityReservationGroupsClient,
+		CommunityGalleryImagesClient:        &communityGalleryImagesClient,
+		CommunityGalleryImageVersionsClient: &communityGalleryImageVersionsClient,
+		DedicatedHostsClient:                &dedicatedHostsClient,
+		DedicatedHostGroupsClient:           &dedicatedHostGroupsClient,
+		DisksClient:                         &disksClient,
+		DiskAccessClient:                    &diskAccessClient,
+		DiskEncryptionSetsClient:            &diskEncryptionSetsClient,
+		GalleriesClient:                     &galleriesClient,
+		GalleryApplicationsClient:           &galleryApplicationsClient,
+		GalleryApplicationVersionsClient:    &galleryApplicationVersionsClient,
+		GalleryImagesClient:                 &galleryImagesClient,
+		GalleryImageVersionsClient:          &galleryImageVersionsClient,
+		GallerySharingProfileClient:         &gallerySharingProfileClient,
+		ImagesClient:                        &imagesClient,
+		ImageTemplatesClient:                &imageTemplatesClient,
+		MarketplaceAgreementsClient:         &marketplaceAgreementsClient,
+		ProximityPlacementGroupsClient:      &proximityPlacementGroupsClient,
+		RestorePointCollectionsClient:       &restorePointCollectionsClient,
+		RestorePointsClient:                 &restorePointsClient,
+		SharedGalleryImagesClient:           &sharedGalleryImagesClient,
+		SharedGalleryImageVersionsClient:    &sharedGalleryImageVersionsClient,
+		SkusClient:                          &skusClient,
+		SSHPublicKeysClient:                 &sshPublicKeysClient,
+		SnapshotsClient:                     &snapshotsClient,
+		UsageClient:                         &usageClient,
+		VirtualMachinesClient:               &virtualMachinesClient,
+		VirtualMachineRunCommandsClient:     &virtualMachineRunCommandsClient,
+		VMExtensionImageClient:              &vmExtensionImageClient,
+		VMExtensionClient:                   &vmExtensionClient,
+		VMScaleSetClient:                    &vmScaleSetClient,
+		VMScaleSetExtensionsClient:          &vmScaleSetExtensionsClient,
+		VMScaleSetRollingUpgradesClient:     &vmScaleSetRollingUpgradesClient,
+		VMScaleSetVMsClient:                 &vmScaleSetVMsClient,
+		VMClient:                            &vmClient,
+		VMImageClient:                       &vmImageClient,
 	}
 }
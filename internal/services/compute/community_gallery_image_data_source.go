@@ -0,0 +1,240 @@
+package compute
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonschema"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/location"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/compute/parse"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/validation"
+	"github.com/hashicorp/terraform-provider-azurerm/utils"
+	"github.com/tombuildsstuff/kermit/sdk/compute/2022-08-01/compute"
+)
+
+type CommunityGalleryImageDataSource struct{}
+
+var _ sdk.DataSource = CommunityGalleryImageDataSource{}
+
+type CommunityGalleryImageDataSourceModel struct {
+	Name                string                          `tfschema:"name"`
+	GalleryName         string                          `tfschema:"gallery_name"`
+	Location            string                          `tfschema:"location"`
+	Architecture        string                          `tfschema:"architecture"`
+	EndOfLifeDate       string                          `tfschema:"end_of_life_date"`
+	Eula                string                          `tfschema:"eula"`
+	HyperVGeneration    string                          `tfschema:"hyper_v_generation"`
+	Identifier          []GalleryImageIdentifierModel   `tfschema:"identifier"`
+	OsType              string                          `tfschema:"os_type"`
+	PrivacyStatementURI string                          `tfschema:"privacy_statement_uri"`
+	PurchasePlan        []GalleryImagePurchasePlanModel `tfschema:"purchase_plan"`
+	Specialized         bool                            `tfschema:"specialized"`
+}
+
+type GalleryImageIdentifierModel struct {
+	Publisher string `tfschema:"publisher"`
+	Offer     string `tfschema:"offer"`
+	Sku       string `tfschema:"sku"`
+}
+
+type GalleryImagePurchasePlanModel struct {
+	Name      string `tfschema:"name"`
+	Publisher string `tfschema:"publisher"`
+	Product   string `tfschema:"product"`
+}
+
+func (r CommunityGalleryImageDataSource) ResourceType() string {
+	return "azurerm_community_gallery_image"
+}
+
+func (r CommunityGalleryImageDataSource) ModelObject() interface{} {
+	return &CommunityGalleryImageDataSourceModel{}
+}
+
+func (r CommunityGalleryImageDataSource) Arguments() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"name": {
+			Type:         pluginsdk.TypeString,
+			Required:     true,
+			ValidateFunc: validation.StringIsNotEmpty,
+		},
+
+		"gallery_name": {
+			Type:         pluginsdk.TypeString,
+			Required:     true,
+			ValidateFunc: validation.StringIsNotEmpty,
+		},
+
+		"location": commonschema.Location(),
+	}
+}
+
+func (r CommunityGalleryImageDataSource) Attributes() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"architecture": {
+			Type:     pluginsdk.TypeString,
+			Computed: true,
+		},
+
+		"end_of_life_date": {
+			Type:     pluginsdk.TypeString,
+			Computed: true,
+		},
+
+		"eula": {
+			Type:     pluginsdk.TypeString,
+			Computed: true,
+		},
+
+		"hyper_v_generation": {
+			Type:     pluginsdk.TypeString,
+			Computed: true,
+		},
+
+		"identifier": galleryImageIdentifierDataSourceSchema(),
+
+		"os_type": {
+			Type:     pluginsdk.TypeString,
+			Computed: true,
+		},
+
+		"privacy_statement_uri": {
+			Type:     pluginsdk.TypeString,
+			Computed: true,
+		},
+
+		"purchase_plan": galleryImagePurchasePlanDataSourceSchema(),
+
+		"specialized": {
+			Type:     pluginsdk.TypeBool,
+			Computed: true,
+		},
+	}
+}
+
+func (r CommunityGalleryImageDataSource) Read() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 5 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.Compute.CommunityGalleryImagesClient
+
+			var state CommunityGalleryImageDataSourceModel
+			if err := metadata.Decode(&state); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			id := parse.NewCommunityGalleryImageID(state.GalleryName, state.Name)
+			loc := location.Normalize(state.Location)
+
+			resp, err := client.Get(ctx, loc, id.GalleryName, id.ImageName)
+			if err != nil {
+				if utils.ResponseWasNotFound(resp.Response) {
+					return fmt.Errorf("%s was not found in %q", id, loc)
+				}
+
+				return fmt.Errorf("retrieving %s: %+v", id, err)
+			}
+
+			state.Location = loc
+
+			if props := resp.CommunityGalleryImageProperties; props != nil {
+				state.Architecture = string(props.Architecture)
+				state.Eula = utils.NormalizeNilableString(props.Eula)
+				state.HyperVGeneration = string(props.HyperVGeneration)
+				state.Identifier = flattenGalleryImageIdentifierModel(props.Identifier)
+				state.OsType = string(props.OsType)
+				state.PrivacyStatementURI = utils.NormalizeNilableString(props.PrivacyStatementURI)
+				state.PurchasePlan = flattenGalleryImagePurchasePlanModel(props.PurchasePlan)
+				state.Specialized = props.OsState == compute.OperatingSystemStateTypesSpecialized
+
+				if props.EndOfLifeDate != nil {
+					state.EndOfLifeDate = props.EndOfLifeDate.Format(time.RFC3339)
+				}
+			}
+
+			metadata.SetID(id)
+			return metadata.Encode(&state)
+		},
+	}
+}
+
+func galleryImageIdentifierDataSourceSchema() *pluginsdk.Schema {
+	return &pluginsdk.Schema{
+		Type:     pluginsdk.TypeList,
+		Computed: true,
+		Elem: &pluginsdk.Resource{
+			Schema: map[string]*pluginsdk.Schema{
+				"publisher": {
+					Type:     pluginsdk.TypeString,
+					Computed: true,
+				},
+
+				"offer": {
+					Type:     pluginsdk.TypeString,
+					Computed: true,
+				},
+
+				"sku": {
+					Type:     pluginsdk.TypeString,
+					Computed: true,
+				},
+			},
+		},
+	}
+}
+
+func galleryImagePurchasePlanDataSourceSchema() *pluginsdk.Schema {
+	return &pluginsdk.Schema{
+		Type:     pluginsdk.TypeList,
+		Computed: true,
+		Elem: &pluginsdk.Resource{
+			Schema: map[string]*pluginsdk.Schema{
+				"name": {
+					Type:     pluginsdk.TypeString,
+					Computed: true,
+				},
+
+				"publisher": {
+					Type:     pluginsdk.TypeString,
+					Computed: true,
+				},
+
+				"product": {
+					Type:     pluginsdk.TypeString,
+					Computed: true,
+				},
+			},
+		},
+	}
+}
+
+func flattenGalleryImageIdentifierModel(input *compute.GalleryImageIdentifier) []GalleryImageIdentifierModel {
+	if input == nil {
+		return []GalleryImageIdentifierModel{}
+	}
+
+	return []GalleryImageIdentifierModel{
+		{
+			Publisher: utils.NormalizeNilableString(input.Publisher),
+			Offer:     utils.NormalizeNilableString(input.Offer),
+			Sku:       utils.NormalizeNilableString(input.Sku),
+		},
+	}
+}
+
+func flattenGalleryImagePurchasePlanModel(input *compute.ImagePurchasePlan) []GalleryImagePurchasePlanModel {
+	if input == nil {
+		return []GalleryImagePurchasePlanModel{}
+	}
+
+	return []GalleryImagePurchasePlanModel{
+		{
+			Name:      utils.NormalizeNilableString(input.Name),
+			Publisher: utils.NormalizeNilableString(input.Publisher),
+			Product:   utils.NormalizeNilableString(input.Product),
+		},
+	}
+}
@@ -0,0 +1,1048 @@
+package compute
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/services/virtualmachineimagebuilder/mgmt/2021-10-01/virtualmachineimagebuilder" // nolint: staticcheck
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonids"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonschema"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/identity"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/location"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/compute/parse"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/compute/validate"
+	networkValidate "github.com/hashicorp/terraform-provider-azurerm/internal/services/network/validate"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tags"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/validation"
+	"github.com/hashicorp/terraform-provider-azurerm/utils"
+)
+
+type ImageBuilderTemplateResource struct{}
+
+var _ sdk.ResourceWithUpdate = ImageBuilderTemplateResource{}
+
+type ImageBuilderTemplateModel struct {
+	Name                       string                                       `tfschema:"name"`
+	ResourceGroupName          string                                       `tfschema:"resource_group_name"`
+	Location                   string                                       `tfschema:"location"`
+	Identity                   []identity.ModelUserAssigned                 `tfschema:"identity"`
+	SourcePlatformImage        []ImageBuilderTemplatePlatformImageModel     `tfschema:"source_platform_image"`
+	SourceManagedImageId       string                                       `tfschema:"source_managed_image_id"`
+	SourceSharedImageVersionId string                                       `tfschema:"source_shared_image_version_id"`
+	Customizer                 []ImageBuilderTemplateCustomizerModel        `tfschema:"customizer"`
+	SharedImageDistributor     []ImageBuilderTemplateSharedImageDistributor `tfschema:"shared_image_distributor"`
+	BuildTimeoutInMinutes      int64                                        `tfschema:"build_timeout_in_minutes"`
+	VMSize                     string                                       `tfschema:"vm_size"`
+	OsDiskSizeGB               int64                                        `tfschema:"os_disk_size_gb"`
+	SubnetId                   string                                       `tfschema:"subnet_id"`
+	BuildTrigger               string                                       `tfschema:"build_trigger"`
+	LastRunStatus              []ImageBuilderTemplateLastRunStatusModel     `tfschema:"last_run_status"`
+	Tags                       map[string]string                            `tfschema:"tags"`
+}
+
+type ImageBuilderTemplatePlatformImageModel struct {
+	Publisher string `tfschema:"publisher"`
+	Offer     string `tfschema:"offer"`
+	Sku       string `tfschema:"sku"`
+	Version   string `tfschema:"version"`
+}
+
+type ImageBuilderTemplateCustomizerModel struct {
+	Type           string   `tfschema:"type"`
+	Name           string   `tfschema:"name"`
+	ScriptUri      string   `tfschema:"script_uri"`
+	Inline         []string `tfschema:"inline"`
+	Sha256Checksum string   `tfschema:"sha256_checksum"`
+	RunElevated    bool     `tfschema:"run_elevated"`
+	RunAsSystem    bool     `tfschema:"run_as_system"`
+	ValidExitCodes []int    `tfschema:"valid_exit_codes"`
+	SourceUri      string   `tfschema:"source_uri"`
+	Destination    string   `tfschema:"destination"`
+	SearchCriteria string   `tfschema:"search_criteria"`
+	Filters        []string `tfschema:"filters"`
+	UpdateLimit    int64    `tfschema:"update_limit"`
+}
+
+type ImageBuilderTemplateSharedImageDistributor struct {
+	SharedImageId      string            `tfschema:"shared_image_id"`
+	RunOutputName      string            `tfschema:"run_output_name"`
+	ReplicationRegions []string          `tfschema:"replication_regions"`
+	ExcludeFromLatest  bool              `tfschema:"exclude_from_latest"`
+	StorageAccountType string            `tfschema:"storage_account_type"`
+	ArtifactTags       map[string]string `tfschema:"artifact_tags"`
+}
+
+type ImageBuilderTemplateLastRunStatusModel struct {
+	RunState    string `tfschema:"run_state"`
+	RunSubState string `tfschema:"run_sub_state"`
+	Message     string `tfschema:"message"`
+	StartTime   string `tfschema:"start_time"`
+	EndTime     string `tfschema:"end_time"`
+}
+
+const (
+	imageBuilderTemplateCustomizerTypeFile          = "File"
+	imageBuilderTemplateCustomizerTypePowerShell    = "PowerShell"
+	imageBuilderTemplateCustomizerTypeShell         = "Shell"
+	imageBuilderTemplateCustomizerTypeWindowsUpdate = "WindowsUpdate"
+)
+
+func (r ImageBuilderTemplateResource) ResourceType() string {
+	return "azurerm_image_builder_template"
+}
+
+func (r ImageBuilderTemplateResource) ModelObject() interface{} {
+	return &ImageBuilderTemplateModel{}
+}
+
+func (r ImageBuilderTemplateResource) IDValidationFunc() pluginsdk.SchemaValidateFunc {
+	return validate.ImageTemplateID
+}
+
+func (r ImageBuilderTemplateResource) Arguments() map[string]*pluginsdk.Schema {
+	sources := []string{"source_platform_image", "source_managed_image_id", "source_shared_image_version_id"}
+
+	return map[string]*pluginsdk.Schema{
+		"name": {
+			Type:         pluginsdk.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: validation.StringIsNotEmpty,
+		},
+
+		"resource_group_name": commonschema.ResourceGroupName(),
+
+		"location": commonschema.Location(),
+
+		"identity": commonschema.UserAssignedIdentityRequired(),
+
+		"source_platform_image": {
+			Type:         pluginsdk.TypeList,
+			Optional:     true,
+			ForceNew:     true,
+			MaxItems:     1,
+			ExactlyOneOf: sources,
+			Elem: &pluginsdk.Resource{
+				Schema: map[string]*pluginsdk.Schema{
+					"publisher": {
+						Type:         pluginsdk.TypeString,
+						Required:     true,
+						ForceNew:     true,
+						ValidateFunc: validation.StringIsNotEmpty,
+					},
+
+					"offer": {
+						Type:         pluginsdk.TypeString,
+						Required:     true,
+						ForceNew:     true,
+						ValidateFunc: validation.StringIsNotEmpty,
+					},
+
+					"sku": {
+						Type:         pluginsdk.TypeString,
+						Required:     true,
+						ForceNew:     true,
+						ValidateFunc: validation.StringIsNotEmpty,
+					},
+
+					"version": {
+						Type:         pluginsdk.TypeString,
+						Required:     true,
+						ForceNew:     true,
+						ValidateFunc: validation.StringIsNotEmpty,
+					},
+				},
+			},
+		},
+
+		"source_managed_image_id": {
+			Type:         pluginsdk.TypeString,
+			Optional:     true,
+			ForceNew:     true,
+			ValidateFunc: validate.ImageID,
+			ExactlyOneOf: sources,
+		},
+
+		"source_shared_image_version_id": {
+			Type:         pluginsdk.TypeString,
+			Optional:     true,
+			ForceNew:     true,
+			ValidateFunc: validate.SharedImageVersionID,
+			ExactlyOneOf: sources,
+		},
+
+		// the customizers are run in the order they're defined, as such this is a List rather than a Set
+		"customizer": {
+			Type:     pluginsdk.TypeList,
+			Optional: true,
+			ForceNew: true,
+			Elem: &pluginsdk.Resource{
+				Schema: map[string]*pluginsdk.Schema{
+					"type": {
+						Type:     pluginsdk.TypeString,
+						Required: true,
+						ForceNew: true,
+						ValidateFunc: validation.StringInSlice([]string{
+							imageBuilderTemplateCustomizerTypeFile,
+							imageBuilderTemplateCustomizerTypePowerShell,
+							imageBuilderTemplateCustomizerTypeShell,
+							imageBuilderTemplateCustomizerTypeWindowsUpdate,
+						}, false),
+					},
+
+					"name": {
+						Type:         pluginsdk.TypeString,
+						Optional:     true,
+						ForceNew:     true,
+						ValidateFunc: validation.StringIsNotEmpty,
+					},
+
+					"script_uri": {
+						Type:         pluginsdk.TypeString,
+						Optional:     true,
+						ForceNew:     true,
+						ValidateFunc: validation.IsURLWithHTTPorHTTPS,
+					},
+
+					"inline": {
+						Type:     pluginsdk.TypeList,
+						Optional: true,
+						ForceNew: true,
+						Elem: &pluginsdk.Schema{
+							Type:         pluginsdk.TypeString,
+							ValidateFunc: validation.StringIsNotEmpty,
+						},
+					},
+
+					"sha256_checksum": {
+						Type:         pluginsdk.TypeString,
+						Optional:     true,
+						ForceNew:     true,
+						ValidateFunc: validation.StringIsNotEmpty,
+					},
+
+					"run_elevated": {
+						Type:     pluginsdk.TypeBool,
+						Optional: true,
+						ForceNew: true,
+						Default:  false,
+					},
+
+					"run_as_system": {
+						Type:     pluginsdk.TypeBool,
+						Optional: true,
+						ForceNew: true,
+						Default:  false,
+					},
+
+					"valid_exit_codes": {
+						Type:     pluginsdk.TypeList,
+						Optional: true,
+						ForceNew: true,
+						Elem: &pluginsdk.Schema{
+							Type: pluginsdk.TypeInt,
+						},
+					},
+
+					"source_uri": {
+						Type:         pluginsdk.TypeString,
+						Optional:     true,
+						ForceNew:     true,
+						ValidateFunc: validation.IsURLWithHTTPorHTTPS,
+					},
+
+					"destination": {
+						Type:         pluginsdk.TypeString,
+						Optional:     true,
+						ForceNew:     true,
+						ValidateFunc: validation.StringIsNotEmpty,
+					},
+
+					"search_criteria": {
+						Type:         pluginsdk.TypeString,
+						Optional:     true,
+						ForceNew:     true,
+						ValidateFunc: validation.StringIsNotEmpty,
+					},
+
+					"filters": {
+						Type:     pluginsdk.TypeList,
+						Optional: true,
+						ForceNew: true,
+						Elem: &pluginsdk.Schema{
+							Type:         pluginsdk.TypeString,
+							ValidateFunc: validation.StringIsNotEmpty,
+						},
+					},
+
+					"update_limit": {
+						Type:         pluginsdk.TypeInt,
+						Optional:     true,
+						ForceNew:     true,
+						ValidateFunc: validation.IntAtLeast(1),
+					},
+				},
+			},
+		},
+
+		"shared_image_distributor": {
+			Type:     pluginsdk.TypeList,
+			Required: true,
+			ForceNew: true,
+			MinItems: 1,
+			Elem: &pluginsdk.Resource{
+				Schema: map[string]*pluginsdk.Schema{
+					"shared_image_id": {
+						Type:         pluginsdk.TypeString,
+						Required:     true,
+						ForceNew:     true,
+						ValidateFunc: validate.SharedImageID,
+					},
+
+					"run_output_name": {
+						Type:         pluginsdk.TypeString,
+						Required:     true,
+						ForceNew:     true,
+						ValidateFunc: validation.StringIsNotEmpty,
+					},
+
+					"replication_regions": {
+						Type:     pluginsdk.TypeList,
+						Optional: true,
+						ForceNew: true,
+						Elem: &pluginsdk.Schema{
+							Type:             pluginsdk.TypeString,
+							ValidateFunc:     location.EnhancedValidate,
+							StateFunc:        location.StateFunc,
+							DiffSuppressFunc: location.DiffSuppressFunc,
+						},
+					},
+
+					"exclude_from_latest": {
+						Type:     pluginsdk.TypeBool,
+						Optional: true,
+						ForceNew: true,
+						Default:  false,
+					},
+
+					"storage_account_type": {
+						Type:     pluginsdk.TypeString,
+						Optional: true,
+						ForceNew: true,
+						Default:  string(virtualmachineimagebuilder.SharedImageStorageAccountTypeStandardLRS),
+						ValidateFunc: validation.StringInSlice([]string{
+							string(virtualmachineimagebuilder.SharedImageStorageAccountTypeStandardLRS),
+							string(virtualmachineimagebuilder.SharedImageStorageAccountTypeStandardZRS),
+						}, false),
+					},
+
+					"artifact_tags": {
+						Type:     pluginsdk.TypeMap,
+						Optional: true,
+						ForceNew: true,
+						Elem: &pluginsdk.Schema{
+							Type: pluginsdk.TypeString,
+						},
+					},
+				},
+			},
+		},
+
+		"build_timeout_in_minutes": {
+			Type:         pluginsdk.TypeInt,
+			Optional:     true,
+			ForceNew:     true,
+			Computed:     true,
+			ValidateFunc: validation.IntBetween(0, 960),
+		},
+
+		"vm_size": {
+			Type:         pluginsdk.TypeString,
+			Optional:     true,
+			ForceNew:     true,
+			Computed:     true,
+			ValidateFunc: validation.StringIsNotEmpty,
+		},
+
+		"os_disk_size_gb": {
+			Type:         pluginsdk.TypeInt,
+			Optional:     true,
+			ForceNew:     true,
+			ValidateFunc: validation.IntAtLeast(0),
+		},
+
+		"subnet_id": {
+			Type:         pluginsdk.TypeString,
+			Optional:     true,
+			ForceNew:     true,
+			ValidateFunc: networkValidate.SubnetID,
+		},
+
+		// the Image Template only defines the build, a build is run when this is set and whenever it's changed
+		"build_trigger": {
+			Type:         pluginsdk.TypeString,
+			Optional:     true,
+			ValidateFunc: validation.StringIsNotEmpty,
+		},
+
+		"tags": tags.Schema(),
+	}
+}
+
+func (r ImageBuilderTemplateResource) Attributes() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"last_run_status": {
+			Type:     pluginsdk.TypeList,
+			Computed: true,
+			Elem: &pluginsdk.Resource{
+				Schema: map[string]*pluginsdk.Schema{
+					"run_state": {
+						Type:     pluginsdk.TypeString,
+						Computed: true,
+					},
+
+					"run_sub_state": {
+						Type:     pluginsdk.TypeString,
+						Computed: true,
+					},
+
+					"message": {
+						Type:     pluginsdk.TypeString,
+						Computed: true,
+					},
+
+					"start_time": {
+						Type:     pluginsdk.TypeString,
+						Computed: true,
+					},
+
+					"end_time": {
+						Type:     pluginsdk.TypeString,
+						Computed: true,
+					},
+				},
+			},
+		},
+	}
+}
+
+func (r ImageBuilderTemplateResource) Create() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		// this includes the time taken to run the build when `build_trigger` is specified
+		Timeout: 6 * time.Hour,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.Compute.ImageTemplatesClient
+			subscriptionId := metadata.Client.Account.SubscriptionId
+
+			var model ImageBuilderTemplateModel
+			if err := metadata.Decode(&model); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			id := parse.NewImageTemplateID(subscriptionId, model.ResourceGroupName, model.Name)
+
+			existing, err := client.Get(ctx, id.ResourceGroup, id.Name)
+			if err != nil {
+				if !utils.ResponseWasNotFound(existing.Response) {
+					return fmt.Errorf("checking for the presence of an existing %s: %+v", id, err)
+				}
+			}
+			if !utils.ResponseWasNotFound(existing.Response) {
+				return metadata.ResourceRequiresImport(r.ResourceType(), id)
+			}
+
+			templateIdentity, err := expandImageBuilderTemplateIdentity(model.Identity)
+			if err != nil {
+				return fmt.Errorf("expanding `identity`: %+v", err)
+			}
+
+			customizers, err := expandImageBuilderTemplateCustomizers(model.Customizer)
+			if err != nil {
+				return err
+			}
+
+			props := &virtualmachineimagebuilder.ImageTemplateProperties{
+				Source:     expandImageBuilderTemplateSource(model),
+				Customize:  customizers,
+				Distribute: expandImageBuilderTemplateSharedImageDistributors(model.SharedImageDistributor),
+				VMProfile: &virtualmachineimagebuilder.ImageTemplateVMProfile{
+					OsDiskSizeGB: utils.Int32(int32(model.OsDiskSizeGB)),
+				},
+			}
+
+			if model.BuildTimeoutInMinutes != 0 {
+				props.BuildTimeoutInMinutes = utils.Int32(int32(model.BuildTimeoutInMinutes))
+			}
+
+			if model.VMSize != "" {
+				props.VMProfile.VMSize = utils.String(model.VMSize)
+			}
+
+			if model.SubnetId != "" {
+				props.VMProfile.VnetConfig = &virtualmachineimagebuilder.VirtualNetworkConfig{
+					SubnetID: utils.String(model.SubnetId),
+				}
+			}
+
+			parameters := virtualmachineimagebuilder.ImageTemplate{
+				Location:                utils.String(location.Normalize(model.Location)),
+				Identity:                templateIdentity,
+				ImageTemplateProperties: props,
+				Tags:                    tags.FromTypedObject(model.Tags),
+			}
+
+			future, err := client.CreateOrUpdate(ctx, parameters, id.ResourceGroup, id.Name)
+			if err != nil {
+				return fmt.Errorf("creating %s: %+v", id, err)
+			}
+
+			if err := future.WaitForCompletionRef(ctx, client.Client); err != nil {
+				return fmt.Errorf("waiting for the creation of %s: %+v", id, err)
+			}
+
+			metadata.SetID(id)
+
+			if model.BuildTrigger != "" {
+				if err := runImageBuilderTemplate(ctx, client, id); err != nil {
+					return err
+				}
+			}
+
+			return nil
+		},
+	}
+}
+
+func (r ImageBuilderTemplateResource) Read() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 5 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.Compute.ImageTemplatesClient
+
+			id, err := parse.ImageTemplateID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			resp, err := client.Get(ctx, id.ResourceGroup, id.Name)
+			if err != nil {
+				if utils.ResponseWasNotFound(resp.Response) {
+					return metadata.MarkAsGone(id)
+				}
+
+				return fmt.Errorf("retrieving %s: %+v", *id, err)
+			}
+
+			state := ImageBuilderTemplateModel{
+				Name:              id.Name,
+				ResourceGroupName: id.ResourceGroup,
+				Location:          location.NormalizeNilable(resp.Location),
+				Tags:              tags.ToTypedObject(resp.Tags),
+			}
+
+			// the build trigger is only used by Terraform, so is retained from the config/state
+			if v, ok := metadata.ResourceData.GetOk("build_trigger"); ok {
+				state.BuildTrigger = v.(string)
+			}
+
+			templateIdentity, err := flattenImageBuilderTemplateIdentity(resp.Identity)
+			if err != nil {
+				return fmt.Errorf("flattening `identity`: %+v", err)
+			}
+			state.Identity = templateIdentity
+
+			if props := resp.ImageTemplateProperties; props != nil {
+				if props.BuildTimeoutInMinutes != nil {
+					state.BuildTimeoutInMinutes = int64(*props.BuildTimeoutInMinutes)
+				}
+
+				if vmProfile := props.VMProfile; vmProfile != nil {
+					if vmProfile.VMSize != nil {
+						state.VMSize = *vmProfile.VMSize
+					}
+					if vmProfile.OsDiskSizeGB != nil {
+						state.OsDiskSizeGB = int64(*vmProfile.OsDiskSizeGB)
+					}
+					if vmProfile.VnetConfig != nil && vmProfile.VnetConfig.SubnetID != nil {
+						state.SubnetId = *vmProfile.VnetConfig.SubnetID
+					}
+				}
+
+				if err := flattenImageBuilderTemplateSource(props.Source, &state); err != nil {
+					return err
+				}
+
+				state.Customizer = flattenImageBuilderTemplateCustomizers(props.Customize)
+				state.SharedImageDistributor = flattenImageBuilderTemplateSharedImageDistributors(props.Distribute)
+				state.LastRunStatus = flattenImageBuilderTemplateLastRunStatus(props.LastRunStatus)
+			}
+
+			return metadata.Encode(&state)
+		},
+	}
+}
+
+func (r ImageBuilderTemplateResource) Update() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		// this includes the time taken to run the build when `build_trigger` is changed
+		Timeout: 6 * time.Hour,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.Compute.ImageTemplatesClient
+
+			id, err := parse.ImageTemplateID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			var model ImageBuilderTemplateModel
+			if err := metadata.Decode(&model); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			if metadata.ResourceData.HasChanges("identity", "tags") {
+				parameters := virtualmachineimagebuilder.ImageTemplateUpdateParameters{
+					Tags: tags.FromTypedObject(model.Tags),
+				}
+
+				if metadata.ResourceData.HasChange("identity") {
+					templateIdentity, err := expandImageBuilderTemplateIdentity(model.Identity)
+					if err != nil {
+						return fmt.Errorf("expanding `identity`: %+v", err)
+					}
+					parameters.Identity = templateIdentity
+				}
+
+				future, err := client.Update(ctx, parameters, id.ResourceGroup, id.Name)
+				if err != nil {
+					return fmt.Errorf("updating %s: %+v", *id, err)
+				}
+
+				if err := future.WaitForCompletionRef(ctx, client.Client); err != nil {
+					return fmt.Errorf("waiting for the update of %s: %+v", *id, err)
+				}
+			}
+
+			if metadata.ResourceData.HasChange("build_trigger") && model.BuildTrigger != "" {
+				if err := runImageBuilderTemplate(ctx, client, *id); err != nil {
+					// restore the previous `build_trigger` so that the build is attempted again during the next apply,
+					// since otherwise the new value would be persisted into the state even though the build failed
+					oldBuildTrigger, _ := metadata.ResourceData.GetChange("build_trigger")
+					if setErr := metadata.ResourceData.Set("build_trigger", oldBuildTrigger); setErr != nil {
+						return fmt.Errorf("setting `build_trigger`: %+v", setErr)
+					}
+					return err
+				}
+			}
+
+			return nil
+		},
+	}
+}
+
+func (r ImageBuilderTemplateResource) Delete() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 60 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.Compute.ImageTemplatesClient
+
+			id, err := parse.ImageTemplateID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			future, err := client.Delete(ctx, id.ResourceGroup, id.Name)
+			if err != nil {
+				return fmt.Errorf("deleting %s: %+v", *id, err)
+			}
+
+			if err := future.WaitForCompletionRef(ctx, client.Client); err != nil {
+				return fmt.Errorf("waiting for the deletion of %s: %+v", *id, err)
+			}
+
+			return nil
+		},
+	}
+}
+
+// runImageBuilderTemplate runs a build of the Image Template and waits for it to complete, returning an error when
+// the build didn't succeed
+func runImageBuilderTemplate(ctx context.Context, client *virtualmachineimagebuilder.VirtualMachineImageTemplatesClient, id parse.ImageTemplateId) error {
+	future, err := client.Run(ctx, id.ResourceGroup, id.Name)
+	if err != nil {
+		return fmt.Errorf("running a build of %s: %+v", id, err)
+	}
+
+	if err := future.WaitForCompletionRef(ctx, client.Client); err != nil {
+		return fmt.Errorf("waiting for the build of %s: %+v", id, err)
+	}
+
+	resp, err := client.Get(ctx, id.ResourceGroup, id.Name)
+	if err != nil {
+		return fmt.Errorf("retrieving %s: %+v", id, err)
+	}
+
+	if props := resp.ImageTemplateProperties; props != nil && props.LastRunStatus != nil {
+		status := props.LastRunStatus
+		if status.RunState != virtualmachineimagebuilder.RunStateSucceeded {
+			message := ""
+			if status.Message != nil {
+				message = *status.Message
+			}
+			return fmt.Errorf("the build of %s finished with the state %q: %s", id, string(status.RunState), message)
+		}
+	}
+
+	return nil
+}
+
+func expandImageBuilderTemplateIdentity(input []identity.ModelUserAssigned) (*virtualmachineimagebuilder.ImageTemplateIdentity, error) {
+	expanded, err := identity.ExpandUserAssignedMapFromModel(input)
+	if err != nil {
+		return nil, err
+	}
+
+	userAssignedIdentities := make(map[string]*virtualmachineimagebuilder.ImageTemplateIdentityUserAssignedIdentitiesValue)
+	for k := range expanded.IdentityIds {
+		userAssignedIdentities[k] = &virtualmachineimagebuilder.ImageTemplateIdentityUserAssignedIdentitiesValue{}
+	}
+
+	return &virtualmachineimagebuilder.ImageTemplateIdentity{
+		Type:                   virtualmachineimagebuilder.ResourceIdentityType(expanded.Type),
+		UserAssignedIdentities: userAssignedIdentities,
+	}, nil
+}
+
+func flattenImageBuilderTemplateIdentity(input *virtualmachineimagebuilder.ImageTemplateIdentity) ([]identity.ModelUserAssigned, error) {
+	var transform *identity.UserAssignedMap
+
+	if input != nil {
+		transform = &identity.UserAssignedMap{
+			Type:        identity.Type(string(input.Type)),
+			IdentityIds: make(map[string]identity.UserAssignedIdentityDetails),
+		}
+
+		for k, v := range input.UserAssignedIdentities {
+			identityId, err := commonids.ParseUserAssignedIdentityIDInsensitively(k)
+			if err != nil {
+				return nil, err
+			}
+
+			details := identity.UserAssignedIdentityDetails{}
+			if v != nil {
+				details.ClientId = v.ClientID
+				details.PrincipalId = v.PrincipalID
+			}
+			transform.IdentityIds[identityId.ID()] = details
+		}
+	}
+
+	output, err := identity.FlattenUserAssignedMapToModel(transform)
+	if err != nil {
+		return nil, err
+	}
+
+	return *output, nil
+}
+
+func expandImageBuilderTemplateSource(input ImageBuilderTemplateModel) virtualmachineimagebuilder.BasicImageTemplateSource {
+	if len(input.SourcePlatformImage) > 0 {
+		platformImage := input.SourcePlatformImage[0]
+		return virtualmachineimagebuilder.ImageTemplatePlatformImageSource{
+			Publisher: utils.String(platformImage.Publisher),
+			Offer:     utils.String(platformImage.Offer),
+			Sku:       utils.String(platformImage.Sku),
+			Version:   utils.String(platformImage.Version),
+		}
+	}
+
+	if input.SourceManagedImageId != "" {
+		return virtualmachineimagebuilder.ImageTemplateManagedImageSource{
+			ImageID: utils.String(input.SourceManagedImageId),
+		}
+	}
+
+	return virtualmachineimagebuilder.ImageTemplateSharedImageVersionSource{
+		ImageVersionID: utils.String(input.SourceSharedImageVersionId),
+	}
+}
+
+func flattenImageBuilderTemplateSource(input virtualmachineimagebuilder.BasicImageTemplateSource, state *ImageBuilderTemplateModel) error {
+	if input == nil {
+		return nil
+	}
+
+	if source, ok := input.AsImageTemplatePlatformImageSource(); ok && source != nil {
+		state.SourcePlatformImage = []ImageBuilderTemplatePlatformImageModel{
+			{
+				Publisher: utils.NormalizeNilableString(source.Publisher),
+				Offer:     utils.NormalizeNilableString(source.Offer),
+				Sku:       utils.NormalizeNilableString(source.Sku),
+				Version:   utils.NormalizeNilableString(source.Version),
+			},
+		}
+		return nil
+	}
+
+	if source, ok := input.AsImageTemplateManagedImageSource(); ok && source != nil && source.ImageID != nil {
+		imageId, err := parse.ImageID(*source.ImageID)
+		if err != nil {
+			return err
+		}
+		state.SourceManagedImageId = imageId.ID()
+		return nil
+	}
+
+	if source, ok := input.AsImageTemplateSharedImageVersionSource(); ok && source != nil && source.ImageVersionID != nil {
+		imageVersionId, err := parse.SharedImageVersionID(*source.ImageVersionID)
+		if err != nil {
+			return err
+		}
+		state.SourceSharedImageVersionId = imageVersionId.ID()
+	}
+
+	return nil
+}
+
+func expandImageBuilderTemplateCustomizers(input []ImageBuilderTemplateCustomizerModel) (*[]virtualmachineimagebuilder.BasicImageTemplateCustomizer, error) {
+	output := make([]virtualmachineimagebuilder.BasicImageTemplateCustomizer, 0)
+
+	for i, v := range input {
+		var name *string
+		if v.Name != "" {
+			name = utils.String(v.Name)
+		}
+
+		var sha256Checksum *string
+		if v.Sha256Checksum != "" {
+			sha256Checksum = utils.String(v.Sha256Checksum)
+		}
+
+		switch v.Type {
+		case imageBuilderTemplateCustomizerTypeShell, imageBuilderTemplateCustomizerTypePowerShell:
+			if (v.ScriptUri == "") == (len(v.Inline) == 0) {
+				return nil, fmt.Errorf("exactly one of `script_uri` or `inline` must be specified for the `%s` customizer `customizer.%d`", v.Type, i)
+			}
+
+			var scriptUri *string
+			if v.ScriptUri != "" {
+				scriptUri = utils.String(v.ScriptUri)
+			}
+
+			var inline *[]string
+			if len(v.Inline) > 0 {
+				inline = pointer.To(v.Inline)
+			}
+
+			if v.Type == imageBuilderTemplateCustomizerTypeShell {
+				output = append(output, virtualmachineimagebuilder.ImageTemplateShellCustomizer{
+					Name:           name,
+					ScriptURI:      scriptUri,
+					Inline:         inline,
+					Sha256Checksum: sha256Checksum,
+				})
+				continue
+			}
+
+			if v.RunAsSystem && !v.RunElevated {
+				return nil, fmt.Errorf("`run_elevated` must be enabled when `run_as_system` is enabled for `customizer.%d`", i)
+			}
+
+			validExitCodes := make([]int32, 0)
+			for _, code := range v.ValidExitCodes {
+				validExitCodes = append(validExitCodes, int32(code))
+			}
+
+			output = append(output, virtualmachineimagebuilder.ImageTemplatePowerShellCustomizer{
+				Name:           name,
+				ScriptURI:      scriptUri,
+				Inline:         inline,
+				Sha256Checksum: sha256Checksum,
+				RunElevated:    utils.Bool(v.RunElevated),
+				RunAsSystem:    utils.Bool(v.RunAsSystem),
+				ValidExitCodes: &validExitCodes,
+			})
+
+		case imageBuilderTemplateCustomizerTypeFile:
+			if v.SourceUri == "" || v.Destination == "" {
+				return nil, fmt.Errorf("`source_uri` and `destination` must be specified for the `File` customizer `customizer.%d`", i)
+			}
+
+			output = append(output, virtualmachineimagebuilder.ImageTemplateFileCustomizer{
+				Name:           name,
+				SourceURI:      utils.String(v.SourceUri),
+				Destination:    utils.String(v.Destination),
+				Sha256Checksum: sha256Checksum,
+			})
+
+		case imageBuilderTemplateCustomizerTypeWindowsUpdate:
+			customizer := virtualmachineimagebuilder.ImageTemplateWindowsUpdateCustomizer{
+				Name:    name,
+				Filters: pointer.To(v.Filters),
+			}
+			if v.SearchCriteria != "" {
+				customizer.SearchCriteria = utils.String(v.SearchCriteria)
+			}
+			if v.UpdateLimit != 0 {
+				customizer.UpdateLimit = utils.Int32(int32(v.UpdateLimit))
+			}
+
+			output = append(output, customizer)
+		}
+	}
+
+	return &output, nil
+}
+
+func flattenImageBuilderTemplateCustomizers(input *[]virtualmachineimagebuilder.BasicImageTemplateCustomizer) []ImageBuilderTemplateCustomizerModel {
+	output := make([]ImageBuilderTemplateCustomizerModel, 0)
+	if input == nil {
+		return output
+	}
+
+	for _, v := range *input {
+		if customizer, ok := v.AsImageTemplateShellCustomizer(); ok && customizer != nil {
+			output = append(output, ImageBuilderTemplateCustomizerModel{
+				Type:           imageBuilderTemplateCustomizerTypeShell,
+				Name:           utils.NormalizeNilableString(customizer.Name),
+				ScriptUri:      utils.NormalizeNilableString(customizer.ScriptURI),
+				Inline:         pointer.From(customizer.Inline),
+				Sha256Checksum: utils.NormalizeNilableString(customizer.Sha256Checksum),
+			})
+			continue
+		}
+
+		if customizer, ok := v.AsImageTemplatePowerShellCustomizer(); ok && customizer != nil {
+			validExitCodes := make([]int, 0)
+			if customizer.ValidExitCodes != nil {
+				for _, code := range *customizer.ValidExitCodes {
+					validExitCodes = append(validExitCodes, int(code))
+				}
+			}
+
+			output = append(output, ImageBuilderTemplateCustomizerModel{
+				Type:           imageBuilderTemplateCustomizerTypePowerShell,
+				Name:           utils.NormalizeNilableString(customizer.Name),
+				ScriptUri:      utils.NormalizeNilableString(customizer.ScriptURI),
+				Inline:         pointer.From(customizer.Inline),
+				Sha256Checksum: utils.NormalizeNilableString(customizer.Sha256Checksum),
+				RunElevated:    utils.NormaliseNilableBool(customizer.RunElevated),
+				RunAsSystem:    utils.NormaliseNilableBool(customizer.RunAsSystem),
+				ValidExitCodes: validExitCodes,
+			})
+			continue
+		}
+
+		if customizer, ok := v.AsImageTemplateFileCustomizer(); ok && customizer != nil {
+			output = append(output, ImageBuilderTemplateCustomizerModel{
+				Type:           imageBuilderTemplateCustomizerTypeFile,
+				Name:           utils.NormalizeNilableString(customizer.Name),
+				SourceUri:      utils.NormalizeNilableString(customizer.SourceURI),
+				Destination:    utils.NormalizeNilableString(customizer.Destination),
+				Sha256Checksum: utils.NormalizeNilableString(customizer.Sha256Checksum),
+			})
+			continue
+		}
+
+		if customizer, ok := v.AsImageTemplateWindowsUpdateCustomizer(); ok && customizer != nil {
+			updateLimit := int64(0)
+			if customizer.UpdateLimit != nil {
+				updateLimit = int64(*customizer.UpdateLimit)
+			}
+
+			output = append(output, ImageBuilderTemplateCustomizerModel{
+				Type:           imageBuilderTemplateCustomizerTypeWindowsUpdate,
+				Name:           utils.NormalizeNilableString(customizer.Name),
+				SearchCriteria: utils.NormalizeNilableString(customizer.SearchCriteria),
+				Filters:        pointer.From(customizer.Filters),
+				UpdateLimit:    updateLimit,
+			})
+		}
+	}
+
+	return output
+}
+
+func expandImageBuilderTemplateSharedImageDistributors(input []ImageBuilderTemplateSharedImageDistributor) *[]virtualmachineimagebuilder.BasicImageTemplateDistributor {
+	output := make([]virtualmachineimagebuilder.BasicImageTemplateDistributor, 0)
+
+	for _, v := range input {
+		replicationRegions := make([]string, 0)
+		for _, region := range v.ReplicationRegions {
+			replicationRegions = append(replicationRegions, location.Normalize(region))
+		}
+
+		output = append(output, virtualmachineimagebuilder.ImageTemplateSharedImageDistributor{
+			GalleryImageID:     utils.String(v.SharedImageId),
+			RunOutputName:      utils.String(v.RunOutputName),
+			ReplicationRegions: &replicationRegions,
+			ExcludeFromLatest:  utils.Bool(v.ExcludeFromLatest),
+			StorageAccountType: virtualmachineimagebuilder.SharedImageStorageAccountType(v.StorageAccountType),
+			ArtifactTags:       tags.FromTypedObject(v.ArtifactTags),
+		})
+	}
+
+	return &output
+}
+
+func flattenImageBuilderTemplateSharedImageDistributors(input *[]virtualmachineimagebuilder.BasicImageTemplateDistributor) []ImageBuilderTemplateSharedImageDistributor {
+	output := make([]ImageBuilderTemplateSharedImageDistributor, 0)
+	if input == nil {
+		return output
+	}
+
+	for _, v := range *input {
+		distributor, ok := v.AsImageTemplateSharedImageDistributor()
+		if !ok || distributor == nil {
+			continue
+		}
+
+		sharedImageId := utils.NormalizeNilableString(distributor.GalleryImageID)
+		if parsed, err := parse.SharedImageID(sharedImageId); err == nil {
+			sharedImageId = parsed.ID()
+		}
+
+		replicationRegions := make([]string, 0)
+		if distributor.ReplicationRegions != nil {
+			for _, region := range *distributor.ReplicationRegions {
+				replicationRegions = append(replicationRegions, location.Normalize(region))
+			}
+		}
+
+		output = append(output, ImageBuilderTemplateSharedImageDistributor{
+			SharedImageId:      sharedImageId,
+			RunOutputName:      utils.NormalizeNilableString(distributor.RunOutputName),
+			ReplicationRegions: replicationRegions,
+			ExcludeFromLatest:  utils.NormaliseNilableBool(distributor.ExcludeFromLatest),
+			StorageAccountType: string(distributor.StorageAccountType),
+			ArtifactTags:       tags.ToTypedObject(distributor.ArtifactTags),
+		})
+	}
+
+	return output
+}
+
+func flattenImageBuilderTemplateLastRunStatus(input *virtualmachineimagebuilder.ImageTemplateLastRunStatus) []ImageBuilderTemplateLastRunStatusModel {
+	if input == nil {
+		return []ImageBuilderTemplateLastRunStatusModel{}
+	}
+
+	output := ImageBuilderTemplateLastRunStatusModel{
+		RunState:    string(input.RunState),
+		RunSubState: string(input.RunSubState),
+		Message:     utils.NormalizeNilableString(input.Message),
+	}
+
+	if input.StartTime != nil {
+		output.StartTime = input.StartTime.Format(time.RFC3339)
+	}
+
+	if input.EndTime != nil {
+		output.EndTime = input.EndTime.Format(time.RFC3339)
+	}
+
+	return []ImageBuilderTemplateLastRunStatusModel{output}
+}
@@ -0,0 +1,352 @@
+package compute_test
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance/check"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/compute/parse"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/utils"
+)
+
+type ImageBuilderTemplateResource struct{}
+
+func TestAccImageBuilderTemplate_basic(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_image_builder_template", "test")
+	r := ImageBuilderTemplateResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func TestAccImageBuilderTemplate_requiresImport(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_image_builder_template", "test")
+	r := ImageBuilderTemplateResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.RequiresImportErrorStep(r.requiresImport),
+	})
+}
+
+func TestAccImageBuilderTemplate_complete(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_image_builder_template", "test")
+	r := ImageBuilderTemplateResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.complete(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func TestAccImageBuilderTemplate_build(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_image_builder_template", "test")
+	r := ImageBuilderTemplateResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(),
+		{
+			Config: r.build(data, "first"),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("last_run_status.0.run_state").HasValue("Succeeded"),
+			),
+		},
+		data.ImportStep("build_trigger"),
+		{
+			Config: r.build(data, "second"),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("last_run_status.0.run_state").HasValue("Succeeded"),
+			),
+		},
+		data.ImportStep("build_trigger"),
+	})
+}
+
+func TestAccImageBuilderTemplate_buildFailed(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_image_builder_template", "test")
+	r := ImageBuilderTemplateResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(),
+		{
+			Config:      r.buildWithCommand(data, "first", "exit 1"),
+			ExpectError: regexp.MustCompile("finished with the state"),
+		},
+		{
+			// confirm that the `build_trigger` which failed to build hasn't been persisted into the state
+			Config:             r.buildWithCommand(data, "first", "exit 1"),
+			PlanOnly:           true,
+			ExpectNonEmptyPlan: true,
+		},
+	})
+}
+
+func (r ImageBuilderTemplateResource) Exists(ctx context.Context, client *clients.Client, state *pluginsdk.InstanceState) (*bool, error) {
+	id, err := parse.ImageTemplateID(state.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := client.Compute.ImageTemplatesClient.Get(ctx, id.ResourceGroup, id.Name)
+	if err != nil {
+		if utils.ResponseWasNotFound(resp.Response) {
+			return utils.Bool(false), nil
+		}
+		return nil, fmt.Errorf("retrieving %s: %+v", id, err)
+	}
+
+	return utils.Bool(resp.ID != nil), nil
+}
+
+func (r ImageBuilderTemplateResource) basic(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_image_builder_template" "test" {
+  name                = "acctestibt-%d"
+  resource_group_name = azurerm_resource_group.test.name
+  location            = azurerm_resource_group.test.location
+
+  identity {
+    type         = "UserAssigned"
+    identity_ids = [azurerm_user_assigned_identity.test.id]
+  }
+
+  source_platform_image {
+    publisher = "Canonical"
+    offer     = "UbuntuServer"
+    sku       = "18.04-LTS"
+    version   = "latest"
+  }
+
+  customizer {
+    type   = "Shell"
+    name   = "hello"
+    inline = ["echo 'hello world'"]
+  }
+
+  shared_image_distributor {
+    shared_image_id = azurerm_shared_image.test.id
+    run_output_name = "acctest"
+  }
+
+  depends_on = [azurerm_role_assignment.test]
+}
+`, r.template(data), data.RandomInteger)
+}
+
+func (r ImageBuilderTemplateResource) requiresImport(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_image_builder_template" "import" {
+  name                = azurerm_image_builder_template.test.name
+  resource_group_name = azurerm_image_builder_template.test.resource_group_name
+  location            = azurerm_image_builder_template.test.location
+
+  identity {
+    type         = "UserAssigned"
+    identity_ids = [azurerm_user_assigned_identity.test.id]
+  }
+
+  source_platform_image {
+    publisher = "Canonical"
+    offer     = "UbuntuServer"
+    sku       = "18.04-LTS"
+    version   = "latest"
+  }
+
+  customizer {
+    type   = "Shell"
+    name   = "hello"
+    inline = ["echo 'hello world'"]
+  }
+
+  shared_image_distributor {
+    shared_image_id = azurerm_shared_image.test.id
+    run_output_name = "acctest"
+  }
+}
+`, r.basic(data))
+}
+
+func (r ImageBuilderTemplateResource) complete(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%[1]s
+
+resource "azurerm_image_builder_template" "test" {
+  name                     = "acctestibt-%[2]d"
+  resource_group_name      = azurerm_resource_group.test.name
+  location                 = azurerm_resource_group.test.location
+  build_timeout_in_minutes = 90
+  vm_size                  = "Standard_D2s_v3"
+  os_disk_size_gb          = 64
+
+  identity {
+    type         = "UserAssigned"
+    identity_ids = [azurerm_user_assigned_identity.test.id]
+  }
+
+  source_platform_image {
+    publisher = "Canonical"
+    offer     = "UbuntuServer"
+    sku       = "18.04-LTS"
+    version   = "latest"
+  }
+
+  customizer {
+    type        = "File"
+    name        = "readme"
+    source_uri  = "https://raw.githubusercontent.com/hashicorp/terraform-provider-azurerm/main/README.md"
+    destination = "/tmp/README.md"
+  }
+
+  customizer {
+    type   = "Shell"
+    name   = "hello"
+    inline = ["cat /tmp/README.md", "echo 'hello world'"]
+  }
+
+  shared_image_distributor {
+    shared_image_id      = azurerm_shared_image.test.id
+    run_output_name      = "acctest"
+    replication_regions  = ["%[3]s"]
+    exclude_from_latest  = true
+    storage_account_type = "Standard_ZRS"
+
+    artifact_tags = {
+      source = "acctest"
+    }
+  }
+
+  tags = {
+    ENV = "Test"
+  }
+
+  depends_on = [azurerm_role_assignment.test]
+}
+`, r.template(data), data.RandomInteger, data.Locations.Secondary)
+}
+
+func (r ImageBuilderTemplateResource) build(data acceptance.TestData, trigger string) string {
+	return r.buildWithCommand(data, trigger, "echo 'hello world'")
+}
+
+func (r ImageBuilderTemplateResource) buildWithCommand(data acceptance.TestData, trigger, command string) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_image_builder_template" "test" {
+  name                = "acctestibt-%d"
+  resource_group_name = azurerm_resource_group.test.name
+  location            = azurerm_resource_group.test.location
+  build_trigger       = "%s"
+
+  identity {
+    type         = "UserAssigned"
+    identity_ids = [azurerm_user_assigned_identity.test.id]
+  }
+
+  source_platform_image {
+    publisher = "Canonical"
+    offer     = "UbuntuServer"
+    sku       = "18.04-LTS"
+    version   = "latest"
+  }
+
+  customizer {
+    type   = "Shell"
+    name   = "hello"
+    inline = ["%s"]
+  }
+
+  shared_image_distributor {
+    shared_image_id = azurerm_shared_image.test.id
+    run_output_name = "acctest"
+  }
+
+  depends_on = [azurerm_role_assignment.test]
+}
+`, r.template(data), data.RandomInteger, trigger, command)
+}
+
+func (r ImageBuilderTemplateResource) template(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+provider "azurerm" {
+  features {}
+}
+
+resource "azurerm_resource_group" "test" {
+  name     = "acctestRG-ibt-%[1]d"
+  location = "%[2]s"
+}
+
+resource "azurerm_user_assigned_identity" "test" {
+  name                = "acctestuai-%[1]d"
+  resource_group_name = azurerm_resource_group.test.name
+  location            = azurerm_resource_group.test.location
+}
+
+resource "azurerm_role_assignment" "test" {
+  scope                = azurerm_resource_group.test.id
+  role_definition_name = "Contributor"
+  principal_id         = azurerm_user_assigned_identity.test.principal_id
+}
+
+resource "azurerm_shared_image_gallery" "test" {
+  name                = "acctestsig%[1]d"
+  resource_group_name = azurerm_resource_group.test.name
+  location            = azurerm_resource_group.test.location
+}
+
+resource "azurerm_shared_image" "test" {
+  name                = "acctestimg%[1]d"
+  gallery_name        = azurerm_shared_image_gallery.test.name
+  resource_group_name = azurerm_resource_group.test.name
+  location            = azurerm_resource_group.test.location
+  os_type             = "Linux"
+
+  identifier {
+    publisher = "AccTesPublisher%[1]d"
+    offer     = "AccTesOffer%[1]d"
+    sku       = "AccTesSku%[1]d"
+  }
+}
+`, data.RandomInteger, data.Locations.Primary)
+}
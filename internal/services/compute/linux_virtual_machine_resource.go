@@ -51,6 +51,8 @@ func resourceLinuxVirtualMachine() *pluginsdk.Resource {
 			Delete: pluginsdk.DefaultTimeout(45 * time.Minute),
 		},
 
+		CustomizeDiff: pluginsdk.CustomizeDiffShim(virtualMachinePowerStateCustomizeDiff),
+
 		Schema: map[string]*pluginsdk.Schema{
 			"name": {
 				Type:         pluginsdk.TypeString,
@@ -229,6 +231,8 @@ func resourceLinuxVirtualMachine() *pluginsdk.Resource {
 
 			"plan": planSchema(),
 
+			"power_state": virtualMachinePowerStateSchema(),
+
 			"priority": {
 				Type:     pluginsdk.TypeString,
 				Optional: true,
@@ -398,6 +402,8 @@ func resourceLinuxVirtualMachineCreate(d *pluginsdk.ResourceData, meta interface
 		return tf.ImportAsExistsError("azurerm_linux_virtual_machine", *resp.ID)
 	}
 
+	powerState := d.Get("power_state").(string)
+
 	additionalCapabilitiesRaw := d.Get("additional_capabilities").([]interface{})
 	additionalCapabilities := expandVirtualMachineAdditionalCapabilities(additionalCapabilitiesRaw)
 
@@ -686,6 +692,14 @@ func resourceLinuxVirtualMachineCreate(d *pluginsdk.ResourceData, meta interface
 	}
 
 	d.SetId(id.ID())
+
+	// the Virtual Machine is running once it's been provisioned, so this only transitions it into another Power State
+	if powerState != "" {
+		if err := setVirtualMachinePowerState(ctx, client, id, powerState); err != nil {
+			return err
+		}
+	}
+
 	return resourceLinuxVirtualMachineRead(d, meta)
 }
 
@@ -916,6 +930,12 @@ func resourceLinuxVirtualMachineRead(d *pluginsdk.ResourceData, meta interface{}
 
 	d.Set("virtual_machine_id", props.VMID)
 
+	instanceView, err := client.InstanceView(ctx, id.ResourceGroup, id.Name)
+	if err != nil {
+		return fmt.Errorf("retrieving InstanceView for Linux Virtual Machine %q (Resource Group %q): %+v", id.Name, id.ResourceGroup, err)
+	}
+	d.Set("power_state", virtualMachinePowerState(instanceView))
+
 	d.Set("user_data", props.UserData)
 
 	zone := ""
@@ -1274,6 +1294,12 @@ func resourceLinuxVirtualMachineUpdate(d *pluginsdk.ResourceData, meta interface
 			shouldDeallocate = true
 		}
 
+		if d.HasChange("additional_capabilities.0.hibernation_enabled") {
+			// hibernation can only be enabled or disabled whilst the Virtual Machine is deallocated
+			shouldShutDown = true
+			shouldDeallocate = true
+		}
+
 		additionalCapabilitiesRaw := d.Get("additional_capabilities").([]interface{})
 		update.VirtualMachineProperties.AdditionalCapabilities = expandVirtualMachineAdditionalCapabilities(additionalCapabilitiesRaw)
 	}
@@ -1439,8 +1465,8 @@ func resourceLinuxVirtualMachineUpdate(d *pluginsdk.ResourceData, meta interface
 		log.Printf("[DEBUG] Updated Linux Virtual Machine %q (Resource Group %q).", id.Name, id.ResourceGroup)
 	}
 
-	// if we've shut it down and it was turned off, let's boot it back up
-	if shouldTurnBackOn && (shouldShutDown || shouldDeallocate) {
+	// if we've shut it down and it was turned off, let's boot it back up - unless it's being transitioned into another power state below
+	if shouldTurnBackOn && (shouldShutDown || shouldDeallocate) && !d.HasChange("power_state") {
 		log.Printf("[DEBUG] Starting Linux Virtual Machine %q (Resource Group %q)..", id.Name, id.ResourceGroup)
 		future, err := client.Start(ctx, id.ResourceGroup, id.Name)
 		if err != nil {
@@ -1454,6 +1480,12 @@ func resourceLinuxVirtualMachineUpdate(d *pluginsdk.ResourceData, meta interface
 		log.Printf("[DEBUG] Started Linux Virtual Machine %q (Resource Group %q)..", id.Name, id.ResourceGroup)
 	}
 
+	if d.HasChange("power_state") {
+		if err := setVirtualMachinePowerState(ctx, client, *id, d.Get("power_state").(string)); err != nil {
+			return err
+		}
+	}
+
 	return resourceLinuxVirtualMachineRead(d, meta)
 }
 
@@ -785,6 +785,8 @@ func resourceLinuxVirtualMachineScaleSetUpdate(d *pluginsdk.ResourceData, meta i
 		AutomaticOSUpgradeIsEnabled:  automaticOSUpgradeIsEnabled,
 		CanRollInstancesWhenRequired: meta.(*clients.Client).Features.VirtualMachineScaleSet.RollInstancesWhenRequired,
 		UpdateInstances:              updateInstances,
+		UpgradeAllInstances:          d.HasChange("upgrade_instances_trigger") && d.Get("upgrade_instances_trigger").(string) != "",
+		WaitForRollingUpgrade:        d.Get("rolling_upgrade_wait_enabled").(bool),
 		Client:                       meta.(*clients.Client).Compute,
 		Existing:                     existing,
 		ID:                           id,
@@ -1062,6 +1064,23 @@ func resourceLinuxVirtualMachineScaleSetRead(d *pluginsdk.ResourceData, meta int
 		}
 	}
 
+	lastRollingUpgradeStatus := make([]interface{}, 0)
+	if props.UpgradePolicy != nil && props.UpgradePolicy.Mode == compute.UpgradeModeRolling {
+		rollingUpgradesClient := meta.(*clients.Client).Compute.VMScaleSetRollingUpgradesClient
+		status, err := rollingUpgradesClient.GetLatest(ctx, id.ResourceGroup, id.Name)
+		if err != nil {
+			// a Scale Set which has never been upgraded has no latest Rolling Upgrade
+			if !utils.ResponseWasNotFound(status.Response) && !utils.ResponseWasConflict(status.Response) {
+				return fmt.Errorf("retrieving the latest Rolling Upgrade for Linux Virtual Machine Scale Set %q (Resource Group %q): %+v", id.Name, id.ResourceGroup, err)
+			}
+		} else {
+			lastRollingUpgradeStatus = FlattenVirtualMachineScaleSetLastRollingUpgradeStatus(&status)
+		}
+	}
+	if err := d.Set("last_rolling_upgrade_status", lastRollingUpgradeStatus); err != nil {
+		return fmt.Errorf("setting `last_rolling_upgrade_status`: %+v", err)
+	}
+
 	return tags.FlattenAndSet(d, resp.Tags)
 }
 
@@ -1341,6 +1360,12 @@ func resourceLinuxVirtualMachineScaleSetSchema() map[string]*pluginsdk.Schema {
 
 		"rolling_upgrade_policy": VirtualMachineScaleSetRollingUpgradePolicySchema(),
 
+		"rolling_upgrade_wait_enabled": {
+			Type:     pluginsdk.TypeBool,
+			Optional: true,
+			Default:  false,
+		},
+
 		"secret": linuxSecretSchema(),
 
 		"secure_boot_enabled": {
@@ -1389,6 +1414,12 @@ func resourceLinuxVirtualMachineScaleSetSchema() map[string]*pluginsdk.Schema {
 			}, false),
 		},
 
+		"upgrade_instances_trigger": {
+			Type:         pluginsdk.TypeString,
+			Optional:     true,
+			ValidateFunc: validation.StringIsNotEmpty,
+		},
+
 		"user_data": {
 			Type:         pluginsdk.TypeString,
 			Optional:     true,
@@ -1417,6 +1448,8 @@ func resourceLinuxVirtualMachineScaleSetSchema() map[string]*pluginsdk.Schema {
 		"zones": commonschema.ZonesMultipleOptionalForceNew(),
 
 		// Computed
+		"last_rolling_upgrade_status": VirtualMachineScaleSetLastRollingUpgradeStatusSchema(),
+
 		"unique_id": {
 			Type:     pluginsdk.TypeString,
 			Computed: true,
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"os"
 	"strings"
 	"time"
 
@@ -160,10 +161,23 @@ func resourceManagedDisk() *pluginsdk.Resource {
 			"upload_size_bytes": {
 				Type:         pluginsdk.TypeInt,
 				Optional:     true,
+				Computed:     true,
 				ForceNew:     true,
 				ValidateFunc: validation.IntAtLeast(1),
 			},
 
+			"upload_source_path": {
+				Type:         pluginsdk.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+
+			"upload_source_content_md5": {
+				Type:     pluginsdk.TypeString,
+				Computed: true,
+			},
+
 			"disk_iops_read_write": {
 				Type:         pluginsdk.TypeInt,
 				Optional:     true,
@@ -294,10 +308,50 @@ func resourceManagedDisk() *pluginsdk.Resource {
 				}
 				return len(old.([]interface{})) > 0 && len(new.([]interface{})) == 0
 			}),
+			pluginsdk.CustomizeDiffShim(managedDiskUploadSourceCustomizeDiff),
 		),
 	}
 }
 
+// managedDiskUploadSourceCustomizeDiff tracks the MD5 of the file specified in `upload_source_path`, so that
+// a change to the contents of the file recreates the Managed Disk
+func managedDiskUploadSourceCustomizeDiff(ctx context.Context, d *pluginsdk.ResourceDiff, meta interface{}) error {
+	if !d.NewValueKnown("upload_source_path") {
+		return d.SetNewComputed("upload_source_content_md5")
+	}
+
+	path := d.Get("upload_source_path").(string)
+	if path == "" {
+		return nil
+	}
+
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		// the file may be created by another resource during the apply
+		return d.SetNewComputed("upload_source_content_md5")
+	}
+
+	contentMD5, err := managedDiskUploadContentMD5(path)
+	if err != nil {
+		return fmt.Errorf("`upload_source_path`: %+v", err)
+	}
+
+	existing := d.Get("upload_source_content_md5").(string)
+	if existing == contentMD5 {
+		return nil
+	}
+
+	if err := d.SetNew("upload_source_content_md5", contentMD5); err != nil {
+		return err
+	}
+
+	// Managed Disks uploaded before the MD5 was tracked only need to record it
+	if d.Id() != "" && existing != "" {
+		return d.ForceNew("upload_source_content_md5")
+	}
+
+	return nil
+}
+
 func resourceManagedDiskCreate(d *pluginsdk.ResourceData, meta interface{}) error {
 	subscriptionId := meta.(*clients.Client).Account.SubscriptionId
 	client := meta.(*clients.Client).Compute.DisksClient
@@ -424,11 +478,28 @@ func resourceManagedDiskCreate(d *pluginsdk.ResourceData, meta interface{}) erro
 		}
 	}
 
+	uploadSourcePath := d.Get("upload_source_path").(string)
+	if uploadSourcePath != "" && createOption != disks.DiskCreateOptionUpload {
+		return fmt.Errorf("`upload_source_path` can only be specified when `create_option` is set to `Upload`")
+	}
+
 	if createOption == disks.DiskCreateOptionUpload {
-		if uploadSizeBytes := d.Get("upload_size_bytes").(int); uploadSizeBytes != 0 {
-			props.CreationData.UploadSizeBytes = utils.Int64(int64(uploadSizeBytes))
+		uploadSizeBytes := int64(d.Get("upload_size_bytes").(int))
+		if uploadSourcePath != "" {
+			sourceSizeBytes, err := managedDiskUploadSize(uploadSourcePath)
+			if err != nil {
+				return fmt.Errorf("`upload_source_path`: %+v", err)
+			}
+			if uploadSizeBytes != 0 && uploadSizeBytes != sourceSizeBytes {
+				return fmt.Errorf("`upload_size_bytes` (%d) must match the size of the file specified in `upload_source_path` (%d)", uploadSizeBytes, sourceSizeBytes)
+			}
+			uploadSizeBytes = sourceSizeBytes
+		}
+
+		if uploadSizeBytes != 0 {
+			props.CreationData.UploadSizeBytes = utils.Int64(uploadSizeBytes)
 		} else {
-			return fmt.Errorf("`upload_size_bytes` must be specified when `create_option` is set to `Upload`")
+			return fmt.Errorf("`upload_size_bytes` or `upload_source_path` must be specified when `create_option` is set to `Upload`")
 		}
 	}
 
@@ -571,6 +642,17 @@ func resourceManagedDiskCreate(d *pluginsdk.ResourceData, meta interface{}) erro
 		return fmt.Errorf("creating/updating Managed Disk %q (Resource Group %q): %+v", name, resourceGroup, err)
 	}
 
+	if uploadSourcePath != "" {
+		// the ID is set prior to uploading so that the Managed Disk is tainted, rather than orphaned, should the upload fail
+		d.SetId(id.ID())
+
+		contentMD5, err := uploadManagedDiskFromSource(ctx, client, id, uploadSourcePath, d.Get("upload_source_content_md5").(string))
+		if err != nil {
+			return err
+		}
+		d.Set("upload_source_content_md5", contentMD5)
+	}
+
 	read, err := client.Get(ctx, id)
 	if err != nil {
 		return fmt.Errorf("retrieving Managed Disk %q (Resource Group %q): %+v", name, resourceGroup, err)
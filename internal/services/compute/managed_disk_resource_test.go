@@ -2,7 +2,9 @@ package compute_test
 
 import (
 	"context"
+	"crypto/rand"
 	"fmt"
+	"os"
 	"strings"
 	"testing"
 	"time"
@@ -93,6 +95,21 @@ func TestAccManagedDisk_copy(t *testing.T) {
 	})
 }
 
+func TestAccManagedDisk_fromDiskRestorePoint(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_managed_disk", "test")
+	r := ManagedDiskResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.fromDiskRestorePoint(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
 func TestAccManagedDisk_fromPlatformImage(t *testing.T) {
 	data := acceptance.BuildTestData(t, "azurerm_managed_disk", "test")
 	r := ManagedDiskResource{}
@@ -138,6 +155,33 @@ func TestAccManagedDisk_upload(t *testing.T) {
 	})
 }
 
+func TestAccManagedDisk_uploadFromLocalFile(t *testing.T) {
+	sourceVhd, err := os.CreateTemp("", "*.vhd")
+	if err != nil {
+		t.Fatalf("Failed to create local source VHD file")
+	}
+	defer os.Remove(sourceVhd.Name())
+
+	if err := populateManagedDiskUploadTempFile(sourceVhd); err != nil {
+		t.Fatalf("Error populating temp file: %s", err)
+	}
+
+	data := acceptance.BuildTestData(t, "azurerm_managed_disk", "test")
+	r := ManagedDiskResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.uploadFromLocalFile(data, sourceVhd.Name()),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("upload_size_bytes").HasValue("33554944"),
+				check.That(data.ResourceName).Key("upload_source_content_md5").Exists(),
+			),
+		},
+		data.ImportStep("upload_source_path", "upload_source_content_md5"),
+	})
+}
+
 func TestAccManagedDisk_update(t *testing.T) {
 	data := acceptance.BuildTestData(t, "azurerm_managed_disk", "test")
 	r := ManagedDiskResource{}
@@ -1002,6 +1046,21 @@ resource "azurerm_managed_disk" "test" {
 `, data.RandomInteger, data.Locations.Primary, data.RandomInteger, data.RandomInteger)
 }
 
+func (ManagedDiskResource) fromDiskRestorePoint(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_managed_disk" "test" {
+  name                 = "acctestd-%d"
+  location             = azurerm_resource_group.test.location
+  resource_group_name  = azurerm_resource_group.test.name
+  storage_account_type = "Standard_LRS"
+  create_option        = "Copy"
+  source_resource_id   = azurerm_restore_point.test.os_disk_restore_point_id
+}
+`, RestorePointResource{}.basic(data), data.RandomInteger)
+}
+
 func (ManagedDiskResource) empty_updated(data acceptance.TestData) string {
 	return fmt.Sprintf(`
 provider "azurerm" {
@@ -1196,6 +1255,53 @@ resource "azurerm_managed_disk" "test" {
 `, data.RandomInteger, data.Locations.Primary, data.RandomInteger)
 }
 
+func (ManagedDiskResource) uploadFromLocalFile(data acceptance.TestData, sourcePath string) string {
+	return fmt.Sprintf(`
+provider "azurerm" {
+  features {}
+}
+
+resource "azurerm_resource_group" "test" {
+  name     = "acctestRG-%d"
+  location = "%s"
+}
+
+resource "azurerm_managed_disk" "test" {
+  name                 = "acctestd-%d"
+  location             = azurerm_resource_group.test.location
+  resource_group_name  = azurerm_resource_group.test.name
+  create_option        = "Upload"
+  storage_account_type = "Standard_LRS"
+  upload_source_path   = "%s"
+}
+`, data.RandomInteger, data.Locations.Primary, data.RandomInteger, sourcePath)
+}
+
+// populateManagedDiskUploadTempFile writes a sparse 32MiB fixed-size VHD, the contents of which are random
+func populateManagedDiskUploadTempFile(input *os.File) error {
+	if err := input.Truncate(32*1024*1024 + 512); err != nil {
+		return fmt.Errorf("Failed to truncate file to 32M")
+	}
+
+	for i := int64(0); i < 32; i += 4 {
+		randomBytes := make([]byte, 1*1024*1024)
+		if _, err := rand.Read(randomBytes); err != nil {
+			return fmt.Errorf("Failed to read random bytes")
+		}
+
+		if _, err := input.WriteAt(randomBytes, i*1024*1024); err != nil {
+			return fmt.Errorf("Failed to write random bytes to file")
+		}
+	}
+
+	// the footer of a VHD begins with the cookie `conectix`
+	if _, err := input.WriteAt([]byte("conectix"), 32*1024*1024); err != nil {
+		return fmt.Errorf("Failed to write the VHD footer to file")
+	}
+
+	return input.Close()
+}
+
 func (ManagedDiskResource) encryptionTemplate(data acceptance.TestData) string {
 	return fmt.Sprintf(`
 provider "azurerm" {
@@ -0,0 +1,306 @@
+package compute
+
+import (
+	"bytes"
+	"context"
+	"crypto/md5"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"runtime"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/go-azure-sdk/resource-manager/compute/2022-03-02/disks"
+)
+
+const (
+	// the length of the footer appended to a fixed-size VHD
+	managedDiskUploadVHDFooterSize int64 = 512
+
+	managedDiskUploadMinPageSize int64 = 4 * 1024
+	managedDiskUploadMaxPageSize int64 = 4 * 1024 * 1024
+
+	managedDiskUploadParallelism = 8
+
+	// the duration the Write SAS is granted for, which needs to cover the upload of the entire VHD
+	managedDiskUploadAccessDurationInSeconds int64 = 86400
+
+	// each page is attempted up to this many times, backing off exponentially when the Storage service is throttling
+	// or returns a transient error
+	managedDiskUploadMaxAttempts = 5
+	managedDiskUploadRetryDelay  = 1 * time.Second
+)
+
+// managedDiskUploadHttpClient is used to write the pages, since these requests go directly to the Storage service
+// rather than through the Resource Manager API
+var managedDiskUploadHttpClient = &http.Client{
+	Timeout: 5 * time.Minute,
+}
+
+// managedDiskUploadSize returns the size of the VHD at the specified path, which must be a fixed-size VHD
+// since the contents of the file are written as-is into the page blob backing the Managed Disk
+func managedDiskUploadSize(path string) (int64, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return 0, fmt.Errorf("retrieving information for %q: %+v", path, err)
+	}
+
+	size := info.Size()
+	if size <= managedDiskUploadVHDFooterSize || (size-managedDiskUploadVHDFooterSize)%(1024*1024) != 0 {
+		return 0, fmt.Errorf("%q must be a fixed-size VHD whose virtual size is a multiple of 1 MiB, but its size was %d bytes", path, size)
+	}
+
+	return size, nil
+}
+
+// managedDiskUploadContentMD5 returns the hex-encoded MD5 of the contents of the file at the specified path
+func managedDiskUploadContentMD5(path string) (string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return "", fmt.Errorf("opening %q: %+v", path, err)
+	}
+	defer file.Close()
+
+	hash := md5.New()
+	if _, err := io.Copy(hash, file); err != nil {
+		return "", fmt.Errorf("reading %q: %+v", path, err)
+	}
+
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// uploadManagedDiskFromSource grants Write access to a Managed Disk created with the `Upload` Create Option,
+// writes the contents of the VHD into it and then revokes access, which finalises the Managed Disk. The MD5 of
+// the file is returned, which is checked against expectedContentMD5 (when specified) prior to uploading and
+// against the contents of the file once the upload has completed.
+func uploadManagedDiskFromSource(ctx context.Context, client *disks.DisksClient, id disks.DiskId, path string, expectedContentMD5 string) (string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return "", fmt.Errorf("opening %q for upload: %+v", path, err)
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return "", fmt.Errorf("retrieving information for %q: %+v", path, err)
+	}
+
+	pageList, contentMD5, err := managedDiskUploadSplitPages(file, info.Size())
+	if err != nil {
+		return "", fmt.Errorf("reading %q: %+v", path, err)
+	}
+	if expectedContentMD5 != "" && contentMD5 != expectedContentMD5 {
+		return "", fmt.Errorf("the MD5 of %q (%s) doesn't match the MD5 determined during the plan (%s), the file has changed since the plan was created", path, contentMD5, expectedContentMD5)
+	}
+
+	log.Printf("[DEBUG] Granting Write access to %s..", id)
+	grantAccessData := disks.GrantAccessData{
+		Access:            disks.AccessLevelWrite,
+		DurationInSeconds: managedDiskUploadAccessDurationInSeconds,
+	}
+	future, err := client.GrantAccess(ctx, id, grantAccessData)
+	if err != nil {
+		return "", fmt.Errorf("granting Write access to %s: %+v", id, err)
+	}
+	if err := future.Poller.PollUntilDone(); err != nil {
+		return "", fmt.Errorf("waiting for Write access to be granted to %s: %+v", id, err)
+	}
+
+	buf := new(bytes.Buffer)
+	if _, err := buf.ReadFrom(future.Poller.HttpResponse.Body); err != nil {
+		return "", fmt.Errorf("reading the SAS for %s: %+v", id, err)
+	}
+	var result Result
+	if err := json.Unmarshal(buf.Bytes(), &result); err != nil {
+		return "", fmt.Errorf("parsing the SAS for %s: %+v", id, err)
+	}
+	if result.Properties.Output.AccessSAS == "" {
+		return "", fmt.Errorf("retrieving the SAS for %s: SAS was nil", id)
+	}
+
+	uploadErr := managedDiskUploadPages(ctx, result.Properties.Output.AccessSAS, pageList)
+
+	// access is always revoked, since a Managed Disk with an active SAS can't be used or deleted
+	log.Printf("[DEBUG] Revoking access to %s..", id)
+	if err := client.RevokeAccessThenPoll(ctx, id); err != nil {
+		return "", fmt.Errorf("revoking access to %s: %+v", id, err)
+	}
+
+	if uploadErr != nil {
+		return "", fmt.Errorf("uploading %q to %s: %+v", path, id, uploadErr)
+	}
+
+	// the pages are read from the file as they're uploaded, so confirm the file didn't change during the upload
+	uploadedContentMD5, err := managedDiskUploadContentMD5(path)
+	if err != nil {
+		return "", fmt.Errorf("verifying the upload of %q to %s: %+v", path, id, err)
+	}
+	if uploadedContentMD5 != contentMD5 {
+		return "", fmt.Errorf("verifying the upload of %q to %s: the MD5 of the file changed from %s to %s during the upload", path, id, contentMD5, uploadedContentMD5)
+	}
+
+	return contentMD5, nil
+}
+
+type managedDiskUploadPage struct {
+	offset  int64
+	section *io.SectionReader
+}
+
+func managedDiskUploadPages(ctx context.Context, sasUrl string, pageList []managedDiskUploadPage) error {
+	// the remaining pages are abandoned as soon as any page fails to upload
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	pages := make(chan managedDiskUploadPage, len(pageList))
+	errors := make(chan error, len(pageList))
+	wg := &sync.WaitGroup{}
+	wg.Add(len(pageList))
+
+	for _, page := range pageList {
+		pages <- page
+	}
+	close(pages)
+
+	workerCount := managedDiskUploadParallelism * runtime.NumCPU()
+	for i := 0; i < workerCount; i++ {
+		go func() {
+			for page := range pages {
+				if ctx.Err() == nil {
+					if err := managedDiskUploadPut(ctx, sasUrl, page); err != nil {
+						errors <- err
+						cancel()
+					}
+				}
+				wg.Done()
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	if len(errors) > 0 {
+		return <-errors
+	}
+
+	return nil
+}
+
+// managedDiskUploadSplitPages chunks the file into pages, skipping any which are empty since
+// the page blob is zeroed when the Managed Disk is created, and returns the hex-encoded MD5 of the file
+func managedDiskUploadSplitPages(file io.ReaderAt, fileSize int64) ([]managedDiskUploadPage, string, error) {
+	hash := md5.New()
+	emptyPage := make([]byte, managedDiskUploadMinPageSize)
+
+	type byteRange struct {
+		offset int64
+		length int64
+	}
+
+	var nonEmptyRanges []byteRange
+	var currentRange byteRange
+	for i := int64(0); i < fileSize; i += managedDiskUploadMinPageSize {
+		pageBuf := make([]byte, managedDiskUploadMinPageSize)
+		n, err := file.ReadAt(pageBuf, i)
+		if err != nil && err != io.EOF {
+			return nil, "", fmt.Errorf("reading chunk at offset %d: %+v", i, err)
+		}
+		hash.Write(pageBuf[:n])
+
+		if bytes.Equal(pageBuf, emptyPage) {
+			if currentRange.length != 0 {
+				nonEmptyRanges = append(nonEmptyRanges, currentRange)
+			}
+			currentRange = byteRange{
+				offset: i + managedDiskUploadMinPageSize,
+			}
+			continue
+		}
+
+		currentRange.length += managedDiskUploadMinPageSize
+		if currentRange.offset+currentRange.length > fileSize {
+			// the VHD footer means the final page can be shorter than the minimum page size
+			currentRange.length = fileSize - currentRange.offset
+		}
+		if currentRange.length >= managedDiskUploadMaxPageSize || currentRange.offset+currentRange.length == fileSize {
+			nonEmptyRanges = append(nonEmptyRanges, currentRange)
+			currentRange = byteRange{
+				offset: i + managedDiskUploadMinPageSize,
+			}
+		}
+	}
+
+	pages := make([]managedDiskUploadPage, 0, len(nonEmptyRanges))
+	for _, nonEmptyRange := range nonEmptyRanges {
+		pages = append(pages, managedDiskUploadPage{
+			offset:  nonEmptyRange.offset,
+			section: io.NewSectionReader(file, nonEmptyRange.offset, nonEmptyRange.length),
+		})
+	}
+
+	return pages, hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// managedDiskUploadPut writes a single page through the SAS, including the MD5 of the page so that
+// the Storage service verifies the integrity of the contents it receives
+func managedDiskUploadPut(ctx context.Context, sasUrl string, page managedDiskUploadPage) error {
+	chunk := make([]byte, page.section.Size())
+	if _, err := page.section.ReadAt(chunk, 0); err != nil && err != io.EOF {
+		return fmt.Errorf("reading page at offset %d: %+v", page.offset, err)
+	}
+
+	hash := md5.Sum(chunk)
+
+	delay := managedDiskUploadRetryDelay
+	for attempt := 1; ; attempt++ {
+		retryable, err := managedDiskUploadPutAttempt(ctx, sasUrl, page.offset, chunk, hash[:])
+		if err == nil {
+			return nil
+		}
+		if !retryable || attempt == managedDiskUploadMaxAttempts {
+			return err
+		}
+
+		log.Printf("[DEBUG] Retrying the write of the page at offset %d in %s (attempt %d of %d): %+v", page.offset, delay, attempt, managedDiskUploadMaxAttempts, err)
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("writing page at offset %d: %+v", page.offset, ctx.Err())
+		case <-time.After(delay):
+		}
+		delay *= 2
+	}
+}
+
+// managedDiskUploadPutAttempt makes a single attempt at writing a page, returning whether a failure is transient
+func managedDiskUploadPutAttempt(ctx context.Context, sasUrl string, offset int64, chunk []byte, hash []byte) (bool, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, sasUrl+"&comp=page", bytes.NewReader(chunk))
+	if err != nil {
+		return false, fmt.Errorf("building request for page at offset %d: %+v", offset, err)
+	}
+	req.ContentLength = int64(len(chunk))
+	req.Header.Set("Content-MD5", base64.StdEncoding.EncodeToString(hash))
+	req.Header.Set("x-ms-page-write", "update")
+	req.Header.Set("x-ms-range", fmt.Sprintf("bytes=%d-%d", offset, offset+int64(len(chunk))-1))
+	req.Header.Set("x-ms-version", "2019-12-12")
+
+	resp, err := managedDiskUploadHttpClient.Do(req)
+	if err != nil {
+		// a cancelled upload isn't retried, whereas other failures to send the request are assumed to be transient
+		return ctx.Err() == nil, fmt.Errorf("writing page at offset %d: %+v", offset, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusCreated {
+		body, _ := io.ReadAll(resp.Body)
+		retryable := resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= http.StatusInternalServerError
+		return retryable, fmt.Errorf("writing page at offset %d: unexpected status %d: %s", offset, resp.StatusCode, string(body))
+	}
+
+	return false, nil
+}
@@ -0,0 +1,139 @@
+package compute
+
+import (
+	"bytes"
+	"context"
+	"crypto/md5"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+)
+
+func TestManagedDiskUploadSplitPages(t *testing.T) {
+	const mib = 1024 * 1024
+
+	testCases := []struct {
+		Name     string
+		Input    func() []byte
+		Expected [][2]int64
+	}{
+		{
+			Name: "Empty Disk",
+			Input: func() []byte {
+				content := make([]byte, mib+512)
+				copy(content[mib:], "conectix")
+				return content
+			},
+			Expected: [][2]int64{
+				{mib, 512},
+			},
+		},
+		{
+			Name: "Sparse Disk",
+			Input: func() []byte {
+				content := make([]byte, 2*mib+512)
+				copy(content[0:], bytes.Repeat([]byte{1}, 8*1024))
+				copy(content[mib:], []byte{1})
+				copy(content[2*mib:], "conectix")
+				return content
+			},
+			Expected: [][2]int64{
+				{0, 8 * 1024},
+				{mib, 4 * 1024},
+				{2 * mib, 512},
+			},
+		},
+		{
+			Name: "Full Disk",
+			Input: func() []byte {
+				return bytes.Repeat([]byte{1}, 5*mib+512)
+			},
+			Expected: [][2]int64{
+				{0, 4 * mib},
+				{4 * mib, mib + 512},
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Logf("Running %q..", testCase.Name)
+
+		content := testCase.Input()
+		pages, contentMD5, err := managedDiskUploadSplitPages(bytes.NewReader(content), int64(len(content)))
+		if err != nil {
+			t.Fatalf("splitting pages: %+v", err)
+		}
+
+		if expectedMD5 := md5.Sum(content); contentMD5 != hex.EncodeToString(expectedMD5[:]) {
+			t.Fatalf("Expected the MD5 to be %x but got %s", expectedMD5, contentMD5)
+		}
+
+		if len(pages) != len(testCase.Expected) {
+			t.Fatalf("Expected %d pages but got %d", len(testCase.Expected), len(pages))
+		}
+
+		for i, page := range pages {
+			if page.offset != testCase.Expected[i][0] || page.section.Size() != testCase.Expected[i][1] {
+				t.Fatalf("Expected page %d to be at offset %d with size %d but got offset %d with size %d", i, testCase.Expected[i][0], testCase.Expected[i][1], page.offset, page.section.Size())
+			}
+		}
+	}
+}
+
+func TestManagedDiskUploadPutRetries(t *testing.T) {
+	testCases := []struct {
+		Name             string
+		Statuses         []int
+		ExpectedRequests int32
+		ExpectError      bool
+	}{
+		{
+			Name:             "Succeeds First Time",
+			Statuses:         []int{http.StatusCreated},
+			ExpectedRequests: 1,
+		},
+		{
+			Name:             "Retries When Unavailable",
+			Statuses:         []int{http.StatusServiceUnavailable, http.StatusCreated},
+			ExpectedRequests: 2,
+		},
+		{
+			Name:             "Doesn't Retry When Forbidden",
+			Statuses:         []int{http.StatusForbidden, http.StatusCreated},
+			ExpectedRequests: 1,
+			ExpectError:      true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Logf("Running %q..", testCase.Name)
+
+		var requests int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			count := atomic.AddInt32(&requests, 1)
+			w.WriteHeader(testCase.Statuses[count-1])
+		}))
+
+		content := bytes.Repeat([]byte{1}, 4*1024)
+		page := managedDiskUploadPage{
+			offset:  0,
+			section: io.NewSectionReader(bytes.NewReader(content), 0, int64(len(content))),
+		}
+
+		err := managedDiskUploadPut(context.Background(), server.URL+"?sig=example", page)
+		server.Close()
+
+		if testCase.ExpectError && err == nil {
+			t.Fatalf("expected an error but didn't get one")
+		}
+		if !testCase.ExpectError && err != nil {
+			t.Fatalf("expected no error but got: %+v", err)
+		}
+		if requests != testCase.ExpectedRequests {
+			t.Fatalf("expected %d requests but got %d", testCase.ExpectedRequests, requests)
+		}
+	}
+}
@@ -0,0 +1,294 @@
+package compute
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonschema"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/location"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/compute/parse"
+	networkParse "github.com/hashicorp/terraform-provider-azurerm/internal/services/network/parse"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/validation"
+	"github.com/hashicorp/terraform-provider-azurerm/utils"
+	"github.com/tombuildsstuff/kermit/sdk/compute/2022-08-01/compute"
+)
+
+type OrchestratedVirtualMachineScaleSetDataSource struct{}
+
+var _ sdk.DataSource = OrchestratedVirtualMachineScaleSetDataSource{}
+
+type OrchestratedVirtualMachineScaleSetDataSourceModel struct {
+	Name              string                                                      `tfschema:"name"`
+	ResourceGroupName string                                                      `tfschema:"resource_group_name"`
+	Location          string                                                      `tfschema:"location"`
+	Instances         []OrchestratedVirtualMachineScaleSetInstanceDataSourceModel `tfschema:"instances"`
+}
+
+type OrchestratedVirtualMachineScaleSetInstanceDataSourceModel struct {
+	Name                string   `tfschema:"name"`
+	VirtualMachineId    string   `tfschema:"virtual_machine_id"`
+	ComputerName        string   `tfschema:"computer_name"`
+	Size                string   `tfschema:"size"`
+	Zone                string   `tfschema:"zone"`
+	PlatformFaultDomain int      `tfschema:"platform_fault_domain"`
+	PowerState          string   `tfschema:"power_state"`
+	HealthState         string   `tfschema:"health_state"`
+	PrivateIPAddress    string   `tfschema:"private_ip_address"`
+	PrivateIPAddresses  []string `tfschema:"private_ip_addresses"`
+}
+
+func (r OrchestratedVirtualMachineScaleSetDataSource) ResourceType() string {
+	return "azurerm_orchestrated_virtual_machine_scale_set"
+}
+
+func (r OrchestratedVirtualMachineScaleSetDataSource) ModelObject() interface{} {
+	return &OrchestratedVirtualMachineScaleSetDataSourceModel{}
+}
+
+func (r OrchestratedVirtualMachineScaleSetDataSource) Arguments() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"name": {
+			Type:         pluginsdk.TypeString,
+			Required:     true,
+			ValidateFunc: validation.StringIsNotEmpty,
+		},
+
+		"resource_group_name": commonschema.ResourceGroupNameForDataSource(),
+	}
+}
+
+func (r OrchestratedVirtualMachineScaleSetDataSource) Attributes() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"location": commonschema.LocationComputed(),
+
+		"instances": {
+			Type:     pluginsdk.TypeList,
+			Computed: true,
+			Elem: &pluginsdk.Resource{
+				Schema: map[string]*pluginsdk.Schema{
+					"name": {
+						Type:     pluginsdk.TypeString,
+						Computed: true,
+					},
+
+					"virtual_machine_id": {
+						Type:     pluginsdk.TypeString,
+						Computed: true,
+					},
+
+					"computer_name": {
+						Type:     pluginsdk.TypeString,
+						Computed: true,
+					},
+
+					"size": {
+						Type:     pluginsdk.TypeString,
+						Computed: true,
+					},
+
+					"zone": {
+						Type:     pluginsdk.TypeString,
+						Computed: true,
+					},
+
+					"platform_fault_domain": {
+						Type:     pluginsdk.TypeInt,
+						Computed: true,
+					},
+
+					"power_state": {
+						Type:     pluginsdk.TypeString,
+						Computed: true,
+					},
+
+					"health_state": {
+						Type:     pluginsdk.TypeString,
+						Computed: true,
+					},
+
+					"private_ip_address": {
+						Type:     pluginsdk.TypeString,
+						Computed: true,
+					},
+
+					"private_ip_addresses": {
+						Type:     pluginsdk.TypeList,
+						Computed: true,
+						Elem: &pluginsdk.Schema{
+							Type: pluginsdk.TypeString,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (r OrchestratedVirtualMachineScaleSetDataSource) Read() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 5 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.Compute.VMScaleSetClient
+			virtualMachinesClient := metadata.Client.Compute.VMClient
+			subscriptionId := metadata.Client.Account.SubscriptionId
+
+			var state OrchestratedVirtualMachineScaleSetDataSourceModel
+			if err := metadata.Decode(&state); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			id := parse.NewVirtualMachineScaleSetID(subscriptionId, state.ResourceGroupName, state.Name)
+
+			resp, err := client.Get(ctx, id.ResourceGroup, id.Name, "")
+			if err != nil {
+				if utils.ResponseWasNotFound(resp.Response) {
+					return fmt.Errorf("%s was not found", id)
+				}
+
+				return fmt.Errorf("retrieving %s: %+v", id, err)
+			}
+
+			if props := resp.VirtualMachineScaleSetProperties; props == nil || props.OrchestrationMode != compute.OrchestrationModeFlexible {
+				return fmt.Errorf("%s does not use the `Flexible` orchestration mode", id)
+			}
+
+			state.Location = location.NormalizeNilable(resp.Location)
+
+			// the instances of a Flexible Scale Set are regular Virtual Machines, rather than Scale Set VMs
+			filter := fmt.Sprintf("'virtualMachineScaleSet/id' eq '%s'", id.ID())
+			result, err := virtualMachinesClient.ListComplete(ctx, id.ResourceGroup, filter)
+			if err != nil {
+				return fmt.Errorf("listing the Virtual Machines within %s: %+v", id, err)
+			}
+
+			state.Instances = make([]OrchestratedVirtualMachineScaleSetInstanceDataSourceModel, 0)
+			for result.NotDone() {
+				instance, err := r.flattenInstance(ctx, metadata, result.Value())
+				if err != nil {
+					return fmt.Errorf("flattening the Virtual Machines within %s: %+v", id, err)
+				}
+				if instance != nil {
+					state.Instances = append(state.Instances, *instance)
+				}
+
+				if err := result.NextWithContext(ctx); err != nil {
+					return fmt.Errorf("listing the next page of Virtual Machines within %s: %+v", id, err)
+				}
+			}
+
+			metadata.SetID(id)
+			return metadata.Encode(&state)
+		},
+	}
+}
+
+func (r OrchestratedVirtualMachineScaleSetDataSource) flattenInstance(ctx context.Context, metadata sdk.ResourceMetaData, input compute.VirtualMachine) (*OrchestratedVirtualMachineScaleSetInstanceDataSourceModel, error) {
+	virtualMachinesClient := metadata.Client.Compute.VMClient
+	networkInterfacesClient := metadata.Client.Network.InterfacesClient
+
+	if input.ID == nil {
+		return nil, nil
+	}
+
+	id, err := parse.VirtualMachineID(*input.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	output := OrchestratedVirtualMachineScaleSetInstanceDataSourceModel{
+		Name:                id.Name,
+		VirtualMachineId:    id.ID(),
+		PlatformFaultDomain: -1,
+		PrivateIPAddresses:  make([]string, 0),
+	}
+
+	if input.Zones != nil && len(*input.Zones) > 0 {
+		output.Zone = (*input.Zones)[0]
+	}
+
+	props := input.VirtualMachineProperties
+	if props == nil {
+		return &output, nil
+	}
+
+	if props.HardwareProfile != nil {
+		output.Size = string(props.HardwareProfile.VMSize)
+	}
+
+	if props.OsProfile != nil && props.OsProfile.ComputerName != nil {
+		output.ComputerName = *props.OsProfile.ComputerName
+	}
+
+	if props.PlatformFaultDomain != nil {
+		output.PlatformFaultDomain = int(*props.PlatformFaultDomain)
+	}
+
+	instanceView, err := virtualMachinesClient.InstanceView(ctx, id.ResourceGroup, id.Name)
+	if err != nil {
+		return nil, fmt.Errorf("retrieving the Instance View for %s: %+v", *id, err)
+	}
+
+	if instanceView.Statuses != nil {
+		for _, status := range *instanceView.Statuses {
+			if status.Code == nil {
+				continue
+			}
+
+			// could also be the provisioning state which we're not bothered with here
+			state := strings.ToLower(*status.Code)
+			if strings.HasPrefix(state, "powerstate/") {
+				output.PowerState = strings.TrimPrefix(state, "powerstate/")
+			}
+		}
+	}
+
+	// the Health State is only available when the Application Health Extension is installed
+	if health := instanceView.VMHealth; health != nil && health.Status != nil && health.Status.Code != nil {
+		output.HealthState = strings.TrimPrefix(strings.ToLower(*health.Status.Code), "healthstate/")
+	}
+
+	if props.NetworkProfile != nil && props.NetworkProfile.NetworkInterfaces != nil {
+		for _, v := range *props.NetworkProfile.NetworkInterfaces {
+			if v.ID == nil {
+				continue
+			}
+
+			nicId, err := networkParse.NetworkInterfaceIDInsensitively(*v.ID)
+			if err != nil {
+				return nil, err
+			}
+
+			nic, err := networkInterfacesClient.Get(ctx, nicId.ResourceGroup, nicId.Name, "")
+			if err != nil {
+				return nil, fmt.Errorf("retrieving %s for %s: %+v", *nicId, *id, err)
+			}
+
+			if nic.InterfacePropertiesFormat == nil || nic.InterfacePropertiesFormat.IPConfigurations == nil {
+				continue
+			}
+
+			primaryNic := v.NetworkInterfaceReferenceProperties != nil && v.NetworkInterfaceReferenceProperties.Primary != nil && *v.NetworkInterfaceReferenceProperties.Primary
+			for _, config := range *nic.InterfacePropertiesFormat.IPConfigurations {
+				configProps := config.InterfaceIPConfigurationPropertiesFormat
+				if configProps == nil || configProps.PrivateIPAddress == nil {
+					continue
+				}
+
+				if primaryNic && configProps.Primary != nil && *configProps.Primary {
+					output.PrivateIPAddress = *configProps.PrivateIPAddress
+				}
+				output.PrivateIPAddresses = append(output.PrivateIPAddresses, *configProps.PrivateIPAddress)
+			}
+		}
+	}
+
+	if output.PrivateIPAddress == "" && len(output.PrivateIPAddresses) > 0 {
+		output.PrivateIPAddress = output.PrivateIPAddresses[0]
+	}
+
+	return &output, nil
+}
@@ -0,0 +1,131 @@
+package parse
+
+// NOTE: this file is generated via 'go:generate' - manual changes will be overwritten
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/resourceids"
+)
+
+type RestorePointId struct {
+	SubscriptionId             string
+	ResourceGroup              string
+	RestorePointCollectionName string
+	Name                       string
+}
+
+func NewRestorePointID(subscriptionId, resourceGroup, restorePointCollectionName, name string) RestorePointId {
+	return RestorePointId{
+		SubscriptionId:             subscriptionId,
+		ResourceGroup:              resourceGroup,
+		RestorePointCollectionName: restorePointCollectionName,
+		Name:                       name,
+	}
+}
+
+func (id RestorePointId) String() string {
+	segments := []string{
+		fmt.Sprintf("Name %q", id.Name),
+		fmt.Sprintf("Restore Point Collection Name %q", id.RestorePointCollectionName),
+		fmt.Sprintf("Resource Group %q", id.ResourceGroup),
+	}
+	segmentsStr := strings.Join(segments, " / ")
+	return fmt.Sprintf("%s: (%s)", "Restore Point", segmentsStr)
+}
+
+func (id RestorePointId) ID() string {
+	fmtString := "/subscriptions/%s/resourceGroups/%s/providers/Microsoft.Compute/restorePointCollections/%s/restorePoints/%s"
+	return fmt.Sprintf(fmtString, id.SubscriptionId, id.ResourceGroup, id.RestorePointCollectionName, id.Name)
+}
+
+// RestorePointID parses a RestorePoint ID into an RestorePointId struct
+func RestorePointID(input string) (*RestorePointId, error) {
+	id, err := resourceids.ParseAzureResourceID(input)
+	if err != nil {
+		return nil, err
+	}
+
+	resourceId := RestorePointId{
+		SubscriptionId: id.SubscriptionID,
+		ResourceGroup:  id.ResourceGroup,
+	}
+
+	if resourceId.SubscriptionId == "" {
+		return nil, fmt.Errorf("ID was missing the 'subscriptions' element")
+	}
+
+	if resourceId.ResourceGroup == "" {
+		return nil, fmt.Errorf("ID was missing the 'resourceGroups' element")
+	}
+
+	if resourceId.RestorePointCollectionName, err = id.PopSegment("restorePointCollections"); err != nil {
+		return nil, err
+	}
+	if resourceId.Name, err = id.PopSegment("restorePoints"); err != nil {
+		return nil, err
+	}
+
+	if err := id.ValidateNoEmptySegments(input); err != nil {
+		return nil, err
+	}
+
+	return &resourceId, nil
+}
+
+// RestorePointIDInsensitively parses an RestorePoint ID into an RestorePointId struct, insensitively
+// This should only be used to parse an ID for rewriting, the RestorePointID
+// method should be used instead for validation etc.
+//
+// Whilst this may seem strange, this enables Terraform have consistent casing
+// which works around issues in Core, whilst handling broken API responses.
+func RestorePointIDInsensitively(input string) (*RestorePointId, error) {
+	id, err := resourceids.ParseAzureResourceID(input)
+	if err != nil {
+		return nil, err
+	}
+
+	resourceId := RestorePointId{
+		SubscriptionId: id.SubscriptionID,
+		ResourceGroup:  id.ResourceGroup,
+	}
+
+	if resourceId.SubscriptionId == "" {
+		return nil, fmt.Errorf("ID was missing the 'subscriptions' element")
+	}
+
+	if resourceId.ResourceGroup == "" {
+		return nil, fmt.Errorf("ID was missing the 'resourceGroups' element")
+	}
+
+	// find the correct casing for the 'restorePointCollections' segment
+	restorePointCollectionsKey := "restorePointCollections"
+	for key := range id.Path {
+		if strings.EqualFold(key, restorePointCollectionsKey) {
+			restorePointCollectionsKey = key
+			break
+		}
+	}
+	if resourceId.RestorePointCollectionName, err = id.PopSegment(restorePointCollectionsKey); err != nil {
+		return nil, err
+	}
+
+	// find the correct casing for the 'restorePoints' segment
+	restorePointsKey := "restorePoints"
+	for key := range id.Path {
+		if strings.EqualFold(key, restorePointsKey) {
+			restorePointsKey = key
+			break
+		}
+	}
+	if resourceId.Name, err = id.PopSegment(restorePointsKey); err != nil {
+		return nil, err
+	}
+
+	if err := id.ValidateNoEmptySegments(input); err != nil {
+		return nil, err
+	}
+
+	return &resourceId, nil
+}
@@ -78,12 +78,23 @@ func (r Registration) SupportedResources() map[string]*pluginsdk.Resource {
 }
 
 func (r Registration) DataSources() []sdk.DataSource {
-	return []sdk.DataSource{}
+	return []sdk.DataSource{
+		CapacityReservationDataSource{},
+		CommunityGalleryImageDataSource{},
+		CommunityGalleryImageVersionDataSource{},
+		OrchestratedVirtualMachineScaleSetDataSource{},
+		SharedGalleryImageDataSource{},
+		SharedGalleryImageVersionDataSource{},
+	}
 }
 
 func (r Registration) Resources() []sdk.Resource {
 	return []sdk.Resource{
 		GalleryApplicationResource{},
 		GalleryApplicationVersionResource{},
+		VirtualMachineRunCommandResource{},
+		RestorePointCollectionResource{},
+		RestorePointResource{},
+		ImageBuilderTemplateResource{},
 	}
 }
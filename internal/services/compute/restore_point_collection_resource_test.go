@@ -0,0 +1,176 @@
+package compute_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance/check"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/compute/parse"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/utils"
+)
+
+type RestorePointCollectionResource struct{}
+
+func TestAccRestorePointCollection_basic(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_restore_point_collection", "test")
+	r := RestorePointCollectionResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func TestAccRestorePointCollection_requiresImport(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_restore_point_collection", "test")
+	r := RestorePointCollectionResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.RequiresImportErrorStep(r.requiresImport),
+	})
+}
+
+func TestAccRestorePointCollection_update(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_restore_point_collection", "test")
+	r := RestorePointCollectionResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(),
+		{
+			Config: r.tags(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("tags.%").HasValue("1"),
+			),
+		},
+		data.ImportStep(),
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func TestAccRestorePointCollection_crossRegionCopy(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_restore_point_collection", "copy")
+	r := RestorePointCollectionResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.crossRegionCopy(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func (r RestorePointCollectionResource) Exists(ctx context.Context, client *clients.Client, state *pluginsdk.InstanceState) (*bool, error) {
+	id, err := parse.RestorePointCollectionID(state.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := client.Compute.RestorePointCollectionsClient.Get(ctx, id.ResourceGroup, id.Name, "")
+	if err != nil {
+		if utils.ResponseWasNotFound(resp.Response) {
+			return utils.Bool(false), nil
+		}
+		return nil, fmt.Errorf("retrieving %s: %+v", id, err)
+	}
+
+	return utils.Bool(resp.ID != nil), nil
+}
+
+func (r RestorePointCollectionResource) basic(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_restore_point_collection" "test" {
+  name                      = "acctestrpc-%d"
+  resource_group_name       = azurerm_resource_group.test.name
+  location                  = azurerm_resource_group.test.location
+  source_virtual_machine_id = azurerm_linux_virtual_machine.test.id
+}
+`, LinuxVirtualMachineResource{}.authPassword(data), data.RandomInteger)
+}
+
+func (r RestorePointCollectionResource) requiresImport(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_restore_point_collection" "import" {
+  name                      = azurerm_restore_point_collection.test.name
+  resource_group_name       = azurerm_restore_point_collection.test.resource_group_name
+  location                  = azurerm_restore_point_collection.test.location
+  source_virtual_machine_id = azurerm_restore_point_collection.test.source_virtual_machine_id
+}
+`, r.basic(data))
+}
+
+func (r RestorePointCollectionResource) tags(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_restore_point_collection" "test" {
+  name                      = "acctestrpc-%d"
+  resource_group_name       = azurerm_resource_group.test.name
+  location                  = azurerm_resource_group.test.location
+  source_virtual_machine_id = azurerm_linux_virtual_machine.test.id
+
+  tags = {
+    ENV = "Test"
+  }
+}
+`, LinuxVirtualMachineResource{}.authPassword(data), data.RandomInteger)
+}
+
+func (r RestorePointCollectionResource) crossRegionCopy(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_restore_point" "test" {
+  name                        = "acctestrp-%[2]d"
+  restore_point_collection_id = azurerm_restore_point_collection.test.id
+}
+
+resource "azurerm_resource_group" "copy" {
+  name     = "acctestRG-rpc-copy-%[2]d"
+  location = "%[3]s"
+}
+
+resource "azurerm_restore_point_collection" "copy" {
+  name                               = "acctestrpc-copy-%[2]d"
+  resource_group_name                = azurerm_resource_group.copy.name
+  location                           = azurerm_resource_group.copy.location
+  source_restore_point_collection_id = azurerm_restore_point_collection.test.id
+
+  depends_on = [azurerm_restore_point.test]
+}
+`, r.basic(data), data.RandomInteger, data.Locations.Secondary)
+}
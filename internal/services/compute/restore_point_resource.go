@@ -0,0 +1,276 @@
+package compute
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/go-azure-sdk/resource-manager/compute/2022-03-02/disks"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/compute/parse"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/compute/validate"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/validation"
+	"github.com/hashicorp/terraform-provider-azurerm/utils"
+	"github.com/tombuildsstuff/kermit/sdk/compute/2022-08-01/compute"
+)
+
+type RestorePointResource struct{}
+
+var _ sdk.Resource = RestorePointResource{}
+
+type RestorePointModel struct {
+	Name                     string   `tfschema:"name"`
+	RestorePointCollectionId string   `tfschema:"restore_point_collection_id"`
+	ConsistencyMode          string   `tfschema:"consistency_mode"`
+	ExcludedDiskIds          []string `tfschema:"excluded_disk_ids"`
+	SourceRestorePointId     string   `tfschema:"source_restore_point_id"`
+	OsDiskRestorePointId     string   `tfschema:"os_disk_restore_point_id"`
+	DataDiskRestorePointIds  []string `tfschema:"data_disk_restore_point_ids"`
+}
+
+func (r RestorePointResource) ResourceType() string {
+	return "azurerm_restore_point"
+}
+
+func (r RestorePointResource) ModelObject() interface{} {
+	return &RestorePointModel{}
+}
+
+func (r RestorePointResource) IDValidationFunc() pluginsdk.SchemaValidateFunc {
+	return validate.RestorePointID
+}
+
+func (r RestorePointResource) Arguments() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"name": {
+			Type:         pluginsdk.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: validation.StringIsNotEmpty,
+		},
+
+		"restore_point_collection_id": {
+			Type:         pluginsdk.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: validate.RestorePointCollectionID,
+		},
+
+		"consistency_mode": {
+			Type:     pluginsdk.TypeString,
+			Optional: true,
+			ForceNew: true,
+			Computed: true,
+			ValidateFunc: validation.StringInSlice([]string{
+				string(compute.ConsistencyModeTypesApplicationConsistent),
+				string(compute.ConsistencyModeTypesCrashConsistent),
+				string(compute.ConsistencyModeTypesFileSystemConsistent),
+			}, false),
+		},
+
+		"excluded_disk_ids": {
+			Type:     pluginsdk.TypeSet,
+			Optional: true,
+			ForceNew: true,
+			Elem: &pluginsdk.Schema{
+				Type:         pluginsdk.TypeString,
+				ValidateFunc: disks.ValidateDiskID,
+			},
+			ConflictsWith: []string{"source_restore_point_id"},
+		},
+
+		// when the Restore Point Collection is a cross-region copy, the Restore Point to copy from the source
+		// Restore Point Collection is specified here
+		"source_restore_point_id": {
+			Type:          pluginsdk.TypeString,
+			Optional:      true,
+			ForceNew:      true,
+			ValidateFunc:  validate.RestorePointID,
+			ConflictsWith: []string{"excluded_disk_ids"},
+		},
+	}
+}
+
+func (r RestorePointResource) Attributes() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"os_disk_restore_point_id": {
+			Type:     pluginsdk.TypeString,
+			Computed: true,
+		},
+
+		"data_disk_restore_point_ids": {
+			Type:     pluginsdk.TypeList,
+			Computed: true,
+			Elem: &pluginsdk.Schema{
+				Type: pluginsdk.TypeString,
+			},
+		},
+	}
+}
+
+func (r RestorePointResource) Create() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 60 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.Compute.RestorePointsClient
+
+			var model RestorePointModel
+			if err := metadata.Decode(&model); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			collectionId, err := parse.RestorePointCollectionID(model.RestorePointCollectionId)
+			if err != nil {
+				return err
+			}
+
+			id := parse.NewRestorePointID(collectionId.SubscriptionId, collectionId.ResourceGroup, collectionId.Name, model.Name)
+
+			existing, err := client.Get(ctx, id.ResourceGroup, id.RestorePointCollectionName, id.Name, "")
+			if err != nil {
+				if !utils.ResponseWasNotFound(existing.Response) {
+					return fmt.Errorf("checking for the presence of an existing %s: %+v", id, err)
+				}
+			}
+			if !utils.ResponseWasNotFound(existing.Response) {
+				return metadata.ResourceRequiresImport(r.ResourceType(), id)
+			}
+
+			props := &compute.RestorePointProperties{
+				ConsistencyMode: compute.ConsistencyModeTypes(model.ConsistencyMode),
+			}
+
+			if len(model.ExcludedDiskIds) > 0 {
+				excludeDisks := make([]compute.APIEntityReference, 0)
+				for _, v := range model.ExcludedDiskIds {
+					excludeDisks = append(excludeDisks, compute.APIEntityReference{
+						ID: utils.String(v),
+					})
+				}
+				props.ExcludeDisks = &excludeDisks
+			}
+
+			if model.SourceRestorePointId != "" {
+				props.SourceRestorePoint = &compute.APIEntityReference{
+					ID: utils.String(model.SourceRestorePointId),
+				}
+			}
+
+			parameters := compute.RestorePoint{
+				RestorePointProperties: props,
+			}
+
+			future, err := client.Create(ctx, id.ResourceGroup, id.RestorePointCollectionName, id.Name, parameters)
+			if err != nil {
+				return fmt.Errorf("creating %s: %+v", id, err)
+			}
+
+			if err := future.WaitForCompletionRef(ctx, client.Client); err != nil {
+				return fmt.Errorf("waiting for the creation of %s: %+v", id, err)
+			}
+
+			metadata.SetID(id)
+			return nil
+		},
+	}
+}
+
+func (r RestorePointResource) Read() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 5 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.Compute.RestorePointsClient
+
+			id, err := parse.RestorePointID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			resp, err := client.Get(ctx, id.ResourceGroup, id.RestorePointCollectionName, id.Name, "")
+			if err != nil {
+				if utils.ResponseWasNotFound(resp.Response) {
+					return metadata.MarkAsGone(id)
+				}
+
+				return fmt.Errorf("retrieving %s: %+v", *id, err)
+			}
+
+			collectionId := parse.NewRestorePointCollectionID(id.SubscriptionId, id.ResourceGroup, id.RestorePointCollectionName)
+
+			state := RestorePointModel{
+				Name:                     id.Name,
+				RestorePointCollectionId: collectionId.ID(),
+				ExcludedDiskIds:          make([]string, 0),
+				DataDiskRestorePointIds:  make([]string, 0),
+			}
+
+			if props := resp.RestorePointProperties; props != nil {
+				state.ConsistencyMode = string(props.ConsistencyMode)
+
+				// these are ForceNew, so are normalized to avoid the casing returned by the API recreating the resource
+				if props.ExcludeDisks != nil {
+					for _, v := range *props.ExcludeDisks {
+						if v.ID != nil {
+							diskId, err := disks.ParseDiskIDInsensitively(*v.ID)
+							if err != nil {
+								return err
+							}
+							state.ExcludedDiskIds = append(state.ExcludedDiskIds, diskId.ID())
+						}
+					}
+				}
+
+				if props.SourceRestorePoint != nil && props.SourceRestorePoint.ID != nil {
+					sourceRestorePointId, err := parse.RestorePointIDInsensitively(*props.SourceRestorePoint.ID)
+					if err != nil {
+						return err
+					}
+					state.SourceRestorePointId = sourceRestorePointId.ID()
+				}
+
+				if props.SourceMetadata != nil && props.SourceMetadata.StorageProfile != nil {
+					storageProfile := props.SourceMetadata.StorageProfile
+					if osDisk := storageProfile.OsDisk; osDisk != nil && osDisk.DiskRestorePoint != nil && osDisk.DiskRestorePoint.ID != nil {
+						state.OsDiskRestorePointId = *osDisk.DiskRestorePoint.ID
+					}
+
+					if storageProfile.DataDisks != nil {
+						for _, v := range *storageProfile.DataDisks {
+							if v.DiskRestorePoint != nil && v.DiskRestorePoint.ID != nil {
+								state.DataDiskRestorePointIds = append(state.DataDiskRestorePointIds, *v.DiskRestorePoint.ID)
+							}
+						}
+					}
+				}
+			}
+
+			return metadata.Encode(&state)
+		},
+	}
+}
+
+func (r RestorePointResource) Delete() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 30 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.Compute.RestorePointsClient
+
+			id, err := parse.RestorePointID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			future, err := client.Delete(ctx, id.ResourceGroup, id.RestorePointCollectionName, id.Name)
+			if err != nil {
+				return fmt.Errorf("deleting %s: %+v", *id, err)
+			}
+
+			if err := future.WaitForCompletionRef(ctx, client.Client); err != nil {
+				return fmt.Errorf("waiting for the deletion of %s: %+v", *id, err)
+			}
+
+			return nil
+		},
+	}
+}
@@ -0,0 +1,136 @@
+package compute
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonschema"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/location"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/compute/parse"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/validation"
+	"github.com/hashicorp/terraform-provider-azurerm/utils"
+	"github.com/tombuildsstuff/kermit/sdk/compute/2022-08-01/compute"
+)
+
+type SharedGalleryImageDataSource struct{}
+
+var _ sdk.DataSource = SharedGalleryImageDataSource{}
+
+type SharedGalleryImageDataSourceModel struct {
+	Name             string                          `tfschema:"name"`
+	GalleryName      string                          `tfschema:"gallery_name"`
+	Location         string                          `tfschema:"location"`
+	Architecture     string                          `tfschema:"architecture"`
+	EndOfLifeDate    string                          `tfschema:"end_of_life_date"`
+	HyperVGeneration string                          `tfschema:"hyper_v_generation"`
+	Identifier       []GalleryImageIdentifierModel   `tfschema:"identifier"`
+	OsType           string                          `tfschema:"os_type"`
+	PurchasePlan     []GalleryImagePurchasePlanModel `tfschema:"purchase_plan"`
+	Specialized      bool                            `tfschema:"specialized"`
+}
+
+func (r SharedGalleryImageDataSource) ResourceType() string {
+	return "azurerm_shared_gallery_image"
+}
+
+func (r SharedGalleryImageDataSource) ModelObject() interface{} {
+	return &SharedGalleryImageDataSourceModel{}
+}
+
+func (r SharedGalleryImageDataSource) Arguments() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"name": {
+			Type:         pluginsdk.TypeString,
+			Required:     true,
+			ValidateFunc: validation.StringIsNotEmpty,
+		},
+
+		"gallery_name": {
+			Type:         pluginsdk.TypeString,
+			Required:     true,
+			ValidateFunc: validation.StringIsNotEmpty,
+		},
+
+		"location": commonschema.Location(),
+	}
+}
+
+func (r SharedGalleryImageDataSource) Attributes() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"architecture": {
+			Type:     pluginsdk.TypeString,
+			Computed: true,
+		},
+
+		"end_of_life_date": {
+			Type:     pluginsdk.TypeString,
+			Computed: true,
+		},
+
+		"hyper_v_generation": {
+			Type:     pluginsdk.TypeString,
+			Computed: true,
+		},
+
+		"identifier": galleryImageIdentifierDataSourceSchema(),
+
+		"os_type": {
+			Type:     pluginsdk.TypeString,
+			Computed: true,
+		},
+
+		"purchase_plan": galleryImagePurchasePlanDataSourceSchema(),
+
+		"specialized": {
+			Type:     pluginsdk.TypeBool,
+			Computed: true,
+		},
+	}
+}
+
+func (r SharedGalleryImageDataSource) Read() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 5 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.Compute.SharedGalleryImagesClient
+
+			var state SharedGalleryImageDataSourceModel
+			if err := metadata.Decode(&state); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			id := parse.NewSharedGalleryImageID(state.GalleryName, state.Name)
+			loc := location.Normalize(state.Location)
+
+			resp, err := client.Get(ctx, loc, id.GalleryName, id.ImageName)
+			if err != nil {
+				if utils.ResponseWasNotFound(resp.Response) {
+					return fmt.Errorf("%s was not found in %q", id, loc)
+				}
+
+				return fmt.Errorf("retrieving %s: %+v", id, err)
+			}
+
+			state.Location = loc
+
+			if props := resp.SharedGalleryImageProperties; props != nil {
+				state.Architecture = string(props.Architecture)
+				state.HyperVGeneration = string(props.HyperVGeneration)
+				state.Identifier = flattenGalleryImageIdentifierModel(props.Identifier)
+				state.OsType = string(props.OsType)
+				state.PurchasePlan = flattenGalleryImagePurchasePlanModel(props.PurchasePlan)
+				state.Specialized = props.OsState == compute.OperatingSystemStateTypesSpecialized
+
+				if props.EndOfLifeDate != nil {
+					state.EndOfLifeDate = props.EndOfLifeDate.Format(time.RFC3339)
+				}
+			}
+
+			metadata.SetID(id)
+			return metadata.Encode(&state)
+		},
+	}
+}
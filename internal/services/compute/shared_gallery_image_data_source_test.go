@@ -0,0 +1,55 @@
+package compute_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance/check"
+)
+
+type SharedGalleryImageDataSource struct{}
+
+func TestAccSharedGalleryImageDataSource_basic(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.azurerm_shared_gallery_image", "test")
+	d := SharedGalleryImageDataSource{}
+
+	data.DataSourceTest(t, []acceptance.TestStep{
+		{
+			Config: d.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("os_type").HasValue("Linux"),
+				check.That(data.ResourceName).Key("specialized").HasValue("false"),
+				check.That(data.ResourceName).Key("hyper_v_generation").HasValue("V1"),
+				check.That(data.ResourceName).Key("identifier.#").HasValue("1"),
+				check.That(data.ResourceName).Key("identifier.0.publisher").HasValue(fmt.Sprintf("AccTesPublisher%d", data.RandomInteger)),
+			),
+		},
+	})
+}
+
+func (SharedGalleryImageDataSource) basic(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%[1]s
+
+resource "azurerm_shared_image" "test" {
+  name                = "acctestimg%[2]d"
+  gallery_name        = azurerm_shared_image_gallery.test.name
+  resource_group_name = azurerm_resource_group.test.name
+  location            = azurerm_resource_group.test.location
+  os_type             = "Linux"
+
+  identifier {
+    publisher = "AccTesPublisher%[2]d"
+    offer     = "AccTesOffer%[2]d"
+    sku       = "AccTesSku%[2]d"
+  }
+}
+
+data "azurerm_shared_gallery_image" "test" {
+  name         = azurerm_shared_image.test.name
+  gallery_name = azurerm_shared_image_gallery.test.unique_name
+  location     = azurerm_resource_group.test.location
+}
+`, SharedImageGalleryResource{}.sharingGroups(data), data.RandomInteger)
+}
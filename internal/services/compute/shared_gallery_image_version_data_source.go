@@ -0,0 +1,135 @@
+package compute
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonschema"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/location"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/compute/parse"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/validation"
+	"github.com/hashicorp/terraform-provider-azurerm/utils"
+)
+
+type SharedGalleryImageVersionDataSource struct{}
+
+var _ sdk.DataSource = SharedGalleryImageVersionDataSource{}
+
+type SharedGalleryImageVersionDataSourceModel struct {
+	Name              string `tfschema:"name"`
+	ImageName         string `tfschema:"image_name"`
+	GalleryName       string `tfschema:"gallery_name"`
+	Location          string `tfschema:"location"`
+	EndOfLifeDate     string `tfschema:"end_of_life_date"`
+	ExcludeFromLatest bool   `tfschema:"exclude_from_latest"`
+	OsDiskImageSizeGb int    `tfschema:"os_disk_image_size_gb"`
+	PublishedDate     string `tfschema:"published_date"`
+}
+
+func (r SharedGalleryImageVersionDataSource) ResourceType() string {
+	return "azurerm_shared_gallery_image_version"
+}
+
+func (r SharedGalleryImageVersionDataSource) ModelObject() interface{} {
+	return &SharedGalleryImageVersionDataSourceModel{}
+}
+
+func (r SharedGalleryImageVersionDataSource) Arguments() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"name": {
+			Type:         pluginsdk.TypeString,
+			Required:     true,
+			ValidateFunc: validation.StringIsNotEmpty,
+		},
+
+		"image_name": {
+			Type:         pluginsdk.TypeString,
+			Required:     true,
+			ValidateFunc: validation.StringIsNotEmpty,
+		},
+
+		"gallery_name": {
+			Type:         pluginsdk.TypeString,
+			Required:     true,
+			ValidateFunc: validation.StringIsNotEmpty,
+		},
+
+		"location": commonschema.Location(),
+	}
+}
+
+func (r SharedGalleryImageVersionDataSource) Attributes() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"end_of_life_date": {
+			Type:     pluginsdk.TypeString,
+			Computed: true,
+		},
+
+		"exclude_from_latest": {
+			Type:     pluginsdk.TypeBool,
+			Computed: true,
+		},
+
+		"os_disk_image_size_gb": {
+			Type:     pluginsdk.TypeInt,
+			Computed: true,
+		},
+
+		"published_date": {
+			Type:     pluginsdk.TypeString,
+			Computed: true,
+		},
+	}
+}
+
+func (r SharedGalleryImageVersionDataSource) Read() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 5 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.Compute.SharedGalleryImageVersionsClient
+
+			var state SharedGalleryImageVersionDataSourceModel
+			if err := metadata.Decode(&state); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			id := parse.NewSharedGalleryImageVersionID(state.GalleryName, state.ImageName, state.Name)
+			loc := location.Normalize(state.Location)
+
+			resp, err := client.Get(ctx, loc, id.GalleryName, id.ImageName, id.Version)
+			if err != nil {
+				if utils.ResponseWasNotFound(resp.Response) {
+					return fmt.Errorf("%s was not found in %q", id, loc)
+				}
+
+				return fmt.Errorf("retrieving %s: %+v", id, err)
+			}
+
+			state.Location = loc
+
+			if props := resp.SharedGalleryImageVersionProperties; props != nil {
+				if props.EndOfLifeDate != nil {
+					state.EndOfLifeDate = props.EndOfLifeDate.Format(time.RFC3339)
+				}
+
+				if props.PublishedDate != nil {
+					state.PublishedDate = props.PublishedDate.Format(time.RFC3339)
+				}
+
+				if props.ExcludeFromLatest != nil {
+					state.ExcludeFromLatest = *props.ExcludeFromLatest
+				}
+
+				if profile := props.StorageProfile; profile != nil && profile.OsDiskImage != nil && profile.OsDiskImage.DiskSizeGB != nil {
+					state.OsDiskImageSizeGb = int(*profile.OsDiskImage.DiskSizeGB)
+				}
+			}
+
+			metadata.SetID(id)
+			return metadata.Encode(&state)
+		},
+	}
+}
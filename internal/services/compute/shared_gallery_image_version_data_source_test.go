@@ -0,0 +1,89 @@
+package compute_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance/check"
+)
+
+type SharedGalleryImageVersionDataSource struct{}
+
+func TestAccSharedGalleryImageVersionDataSource_basic(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.azurerm_shared_gallery_image_version", "test")
+	d := SharedGalleryImageVersionDataSource{}
+
+	data.DataSourceTest(t, []acceptance.TestStep{
+		{
+			// need to create a vm and then reference it in the image creation
+			Config: SharedImageVersionResource{}.setup(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				data.CheckWithClientForResource(ImageResource{}.virtualMachineExists, "azurerm_virtual_machine.testsource"),
+				data.CheckWithClientForResource(ImageResource{}.generalizeVirtualMachine(data), "azurerm_virtual_machine.testsource"),
+			),
+		},
+		{
+			Config: d.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("published_date").Exists(),
+				check.That(data.ResourceName).Key("exclude_from_latest").HasValue("false"),
+				check.That(data.ResourceName).Key("os_disk_image_size_gb").Exists(),
+			),
+		},
+	})
+}
+
+func (SharedGalleryImageVersionDataSource) basic(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%[1]s
+
+data "azurerm_client_config" "current" {}
+
+resource "azurerm_shared_image_gallery" "test" {
+  name                = "acctestsig%[2]d"
+  resource_group_name = azurerm_resource_group.test.name
+  location            = azurerm_resource_group.test.location
+
+  sharing {
+    permission       = "Groups"
+    subscription_ids = [data.azurerm_client_config.current.subscription_id]
+  }
+}
+
+resource "azurerm_shared_image" "test" {
+  name                = "acctestimg%[2]d"
+  gallery_name        = azurerm_shared_image_gallery.test.name
+  resource_group_name = azurerm_resource_group.test.name
+  location            = azurerm_resource_group.test.location
+  os_type             = "Linux"
+
+  identifier {
+    publisher = "AccTesPublisher%[2]d"
+    offer     = "AccTesOffer%[2]d"
+    sku       = "AccTesSku%[2]d"
+  }
+}
+
+resource "azurerm_shared_image_version" "test" {
+  name                = "0.0.1"
+  gallery_name        = azurerm_shared_image_gallery.test.name
+  image_name          = azurerm_shared_image.test.name
+  resource_group_name = azurerm_resource_group.test.name
+  location            = azurerm_resource_group.test.location
+  managed_image_id    = azurerm_image.test.id
+
+  target_region {
+    name                   = azurerm_resource_group.test.location
+    regional_replica_count = 1
+  }
+}
+
+data "azurerm_shared_gallery_image_version" "test" {
+  name         = azurerm_shared_image_version.test.name
+  image_name   = azurerm_shared_image.test.name
+  gallery_name = azurerm_shared_image_gallery.test.unique_name
+  location     = azurerm_resource_group.test.location
+}
+`, ImageResource{}.standaloneImageProvision(data, "LRS", ""), data.RandomInteger)
+}
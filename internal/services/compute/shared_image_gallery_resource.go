@@ -1,6 +1,7 @@
 package compute
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"time"
@@ -14,6 +15,7 @@ import (
 	"github.com/hashicorp/terraform-provider-azurerm/internal/services/compute/validate"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/tags"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/validation"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/timeouts"
 	"github.com/hashicorp/terraform-provider-azurerm/utils"
 	"github.com/tombuildsstuff/kermit/sdk/compute/2022-08-01/compute"
@@ -37,6 +39,13 @@ func resourceSharedImageGallery() *pluginsdk.Resource {
 			Delete: pluginsdk.DefaultTimeout(30 * time.Minute),
 		},
 
+		CustomizeDiff: pluginsdk.CustomDiffInSequence(
+			// the details of a Community Gallery can't be changed once it's been published
+			pluginsdk.ForceNewIfChange("sharing.0.community_gallery", func(ctx context.Context, old, new, meta interface{}) bool {
+				return len(old.([]interface{})) > 0 && len(new.([]interface{})) > 0
+			}),
+		),
+
 		Schema: map[string]*pluginsdk.Schema{
 			"name": {
 				Type:         pluginsdk.TypeString,
@@ -54,6 +63,81 @@ func resourceSharedImageGallery() *pluginsdk.Resource {
 				Optional: true,
 			},
 
+			"sharing": {
+				Type:     pluginsdk.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &pluginsdk.Resource{
+					Schema: map[string]*pluginsdk.Schema{
+						"permission": {
+							Type:     pluginsdk.TypeString,
+							Required: true,
+							ValidateFunc: validation.StringInSlice([]string{
+								string(compute.GallerySharingPermissionTypesCommunity),
+								string(compute.GallerySharingPermissionTypesGroups),
+								string(compute.GallerySharingPermissionTypesPrivate),
+							}, false),
+						},
+
+						"community_gallery": {
+							Type:     pluginsdk.TypeList,
+							Optional: true,
+							MaxItems: 1,
+							Elem: &pluginsdk.Resource{
+								Schema: map[string]*pluginsdk.Schema{
+									"eula": {
+										Type:         pluginsdk.TypeString,
+										Required:     true,
+										ValidateFunc: validation.StringIsNotEmpty,
+									},
+
+									"prefix": {
+										Type:         pluginsdk.TypeString,
+										Required:     true,
+										ValidateFunc: validation.StringIsNotEmpty,
+									},
+
+									"publisher_email": {
+										Type:         pluginsdk.TypeString,
+										Required:     true,
+										ValidateFunc: validation.StringIsNotEmpty,
+									},
+
+									"publisher_uri": {
+										Type:         pluginsdk.TypeString,
+										Required:     true,
+										ValidateFunc: validation.IsURLWithHTTPorHTTPS,
+									},
+
+									"name": {
+										Type:     pluginsdk.TypeString,
+										Computed: true,
+									},
+								},
+							},
+						},
+
+						"subscription_ids": {
+							Type:     pluginsdk.TypeSet,
+							Optional: true,
+							Elem: &pluginsdk.Schema{
+								Type:         pluginsdk.TypeString,
+								ValidateFunc: validation.IsUUID,
+							},
+						},
+
+						"tenant_ids": {
+							Type:     pluginsdk.TypeSet,
+							Optional: true,
+							Elem: &pluginsdk.Schema{
+								Type:         pluginsdk.TypeString,
+								ValidateFunc: validation.IsUUID,
+							},
+						},
+					},
+				},
+			},
+
 			"tags": tags.Schema(),
 
 			"unique_name": {
@@ -66,6 +150,7 @@ func resourceSharedImageGallery() *pluginsdk.Resource {
 
 func resourceSharedImageGalleryCreateUpdate(d *pluginsdk.ResourceData, meta interface{}) error {
 	client := meta.(*clients.Client).Compute.GalleriesClient
+	sharingClient := meta.(*clients.Client).Compute.GallerySharingProfileClient
 	subscriptionId := meta.(*clients.Client).Account.SubscriptionId
 	ctx, cancel := timeouts.ForCreateUpdate(meta.(*clients.Client).StopContext, d)
 	defer cancel()
@@ -77,6 +162,14 @@ func resourceSharedImageGalleryCreateUpdate(d *pluginsdk.ResourceData, meta inte
 	description := d.Get("description").(string)
 	t := d.Get("tags").(map[string]interface{})
 
+	sharing, err := expandSharedImageGallerySharing(d.Get("sharing").([]interface{}))
+	if err != nil {
+		return err
+	}
+
+	oldPermission, newPermission := d.GetChange("sharing.0.permission")
+	permissionChanged := oldPermission.(string) != newPermission.(string)
+
 	if d.IsNewResource() {
 		existing, err := client.Get(ctx, id.ResourceGroup, id.GalleryName, "", "")
 		if err != nil {
@@ -90,10 +183,21 @@ func resourceSharedImageGalleryCreateUpdate(d *pluginsdk.ResourceData, meta inte
 		}
 	}
 
+	// the Sharing Profile has to be reset before a Gallery which is shared with a Community or with Groups can
+	// be moved to another permission, which also removes any Subscriptions and Tenants it's been shared with
+	sharingReset := false
+	if permissionChanged && oldPermission.(string) != "" && oldPermission.(string) != string(compute.GallerySharingPermissionTypesPrivate) {
+		if err := updateSharedImageGallerySharingProfile(ctx, sharingClient, id, compute.SharingUpdate{OperationType: compute.SharingUpdateOperationTypesReset}); err != nil {
+			return err
+		}
+		sharingReset = true
+	}
+
 	gallery := compute.Gallery{
 		Location: utils.String(location),
 		GalleryProperties: &compute.GalleryProperties{
-			Description: utils.String(description),
+			Description:    utils.String(description),
+			SharingProfile: sharing,
 		},
 		Tags: tags.Expand(t),
 	}
@@ -109,6 +213,57 @@ func resourceSharedImageGalleryCreateUpdate(d *pluginsdk.ResourceData, meta inte
 
 	d.SetId(id.ID())
 
+	if permissionChanged && sharing != nil && sharing.Permissions == compute.GallerySharingPermissionTypesCommunity {
+		if err := updateSharedImageGallerySharingProfile(ctx, sharingClient, id, compute.SharingUpdate{OperationType: compute.SharingUpdateOperationTypesEnableCommunity}); err != nil {
+			return err
+		}
+	}
+
+	// the Subscriptions and Tenants a Gallery is shared with can't be set during creation, instead they're
+	// managed through the Gallery Sharing Profile API, which is only able to add or remove them
+	if d.HasChange("sharing") {
+		oldSharing, newSharing := d.GetChange("sharing")
+		existingSharing := oldSharing.([]interface{})
+		if sharingReset {
+			existingSharing = []interface{}{}
+		}
+
+		for _, groupType := range []compute.SharingProfileGroupTypes{compute.SharingProfileGroupTypesSubscriptions, compute.SharingProfileGroupTypesAADTenants} {
+			existing := sharedImageGallerySharingGroupIds(existingSharing, groupType)
+			desired := sharedImageGallerySharingGroupIds(newSharing.([]interface{}), groupType)
+
+			if toRemove := existing.Difference(desired); toRemove.Len() > 0 {
+				update := compute.SharingUpdate{
+					OperationType: compute.SharingUpdateOperationTypesRemove,
+					Groups: &[]compute.SharingProfileGroup{
+						{
+							Type: groupType,
+							Ids:  utils.ExpandStringSlice(toRemove.List()),
+						},
+					},
+				}
+				if err := updateSharedImageGallerySharingProfile(ctx, sharingClient, id, update); err != nil {
+					return err
+				}
+			}
+
+			if toAdd := desired.Difference(existing); toAdd.Len() > 0 {
+				update := compute.SharingUpdate{
+					OperationType: compute.SharingUpdateOperationTypesAdd,
+					Groups: &[]compute.SharingProfileGroup{
+						{
+							Type: groupType,
+							Ids:  utils.ExpandStringSlice(toAdd.List()),
+						},
+					},
+				}
+				if err := updateSharedImageGallerySharingProfile(ctx, sharingClient, id, update); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
 	return resourceSharedImageGalleryRead(d, meta)
 }
 
@@ -122,7 +277,7 @@ func resourceSharedImageGalleryRead(d *pluginsdk.ResourceData, meta interface{})
 		return err
 	}
 
-	resp, err := client.Get(ctx, id.ResourceGroup, id.GalleryName, "", "")
+	resp, err := client.Get(ctx, id.ResourceGroup, id.GalleryName, compute.SelectPermissionsPermissions, "")
 	if err != nil {
 		if utils.ResponseWasNotFound(resp.Response) {
 			log.Printf("[DEBUG] Shared Image Gallery %q (Resource Group %q) was not found - removing from state", id.GalleryName, id.ResourceGroup)
@@ -144,6 +299,16 @@ func resourceSharedImageGalleryRead(d *pluginsdk.ResourceData, meta interface{})
 		if identifier := props.Identifier; identifier != nil {
 			d.Set("unique_name", identifier.UniqueName)
 		}
+
+		// a Gallery which isn't shared is returned with a Permission of `Private`, which is only
+		// surfaced when it's been explicitly configured to avoid a diff for existing Galleries
+		sharing := flattenSharedImageGallerySharing(props.SharingProfile)
+		if len(sharing) > 0 && props.SharingProfile.Permissions == compute.GallerySharingPermissionTypesPrivate && len(d.Get("sharing").([]interface{})) == 0 {
+			sharing = []interface{}{}
+		}
+		if err := d.Set("sharing", sharing); err != nil {
+			return fmt.Errorf("setting `sharing`: %+v", err)
+		}
 	}
 
 	return tags.FlattenAndSet(d, resp.Tags)
@@ -151,6 +316,7 @@ func resourceSharedImageGalleryRead(d *pluginsdk.ResourceData, meta interface{})
 
 func resourceSharedImageGalleryDelete(d *pluginsdk.ResourceData, meta interface{}) error {
 	client := meta.(*clients.Client).Compute.GalleriesClient
+	sharingClient := meta.(*clients.Client).Compute.GallerySharingProfileClient
 	ctx, cancel := timeouts.ForDelete(meta.(*clients.Client).StopContext, d)
 	defer cancel()
 
@@ -159,6 +325,15 @@ func resourceSharedImageGalleryDelete(d *pluginsdk.ResourceData, meta interface{
 		return err
 	}
 
+	// a Gallery which is shared can't be deleted, so the sharing has to be reset first
+	if sharing := d.Get("sharing").([]interface{}); len(sharing) > 0 && sharing[0] != nil {
+		if permission := sharing[0].(map[string]interface{})["permission"].(string); permission != string(compute.GallerySharingPermissionTypesPrivate) {
+			if err := updateSharedImageGallerySharingProfile(ctx, sharingClient, *id, compute.SharingUpdate{OperationType: compute.SharingUpdateOperationTypesReset}); err != nil {
+				return err
+			}
+		}
+	}
+
 	future, err := client.Delete(ctx, id.ResourceGroup, id.GalleryName)
 	if err != nil {
 		return fmt.Errorf("deleting Shared Image Gallery %q (Resource Group %q): %+v", id.GalleryName, id.ResourceGroup, err)
@@ -172,3 +347,122 @@ func resourceSharedImageGalleryDelete(d *pluginsdk.ResourceData, meta interface{
 
 	return nil
 }
+
+func updateSharedImageGallerySharingProfile(ctx context.Context, client *compute.GallerySharingProfileClient, id parse.SharedImageGalleryId, update compute.SharingUpdate) error {
+	future, err := client.Update(ctx, id.ResourceGroup, id.GalleryName, update)
+	if err != nil {
+		return fmt.Errorf("performing %s operation on the Sharing Profile for %s: %+v", update.OperationType, id, err)
+	}
+
+	if err := future.WaitForCompletionRef(ctx, client.Client); err != nil {
+		return fmt.Errorf("waiting for %s operation on the Sharing Profile for %s: %+v", update.OperationType, id, err)
+	}
+
+	return nil
+}
+
+func expandSharedImageGallerySharing(input []interface{}) (*compute.SharingProfile, error) {
+	if len(input) == 0 || input[0] == nil {
+		return nil, nil
+	}
+
+	v := input[0].(map[string]interface{})
+	permission := compute.GallerySharingPermissionTypes(v["permission"].(string))
+
+	output := compute.SharingProfile{
+		Permissions: permission,
+	}
+
+	communityGallery := v["community_gallery"].([]interface{})
+	if permission == compute.GallerySharingPermissionTypesCommunity {
+		if len(communityGallery) == 0 || communityGallery[0] == nil {
+			return nil, fmt.Errorf("`community_gallery` must be specified when `permission` is set to `%s`", compute.GallerySharingPermissionTypesCommunity)
+		}
+
+		raw := communityGallery[0].(map[string]interface{})
+		output.CommunityGalleryInfo = &compute.CommunityGalleryInfo{
+			Eula:             utils.String(raw["eula"].(string)),
+			PublicNamePrefix: utils.String(raw["prefix"].(string)),
+			PublisherContact: utils.String(raw["publisher_email"].(string)),
+			PublisherURI:     utils.String(raw["publisher_uri"].(string)),
+		}
+	} else if len(communityGallery) > 0 {
+		return nil, fmt.Errorf("`community_gallery` can only be specified when `permission` is set to `%s`", compute.GallerySharingPermissionTypesCommunity)
+	}
+
+	if permission != compute.GallerySharingPermissionTypesGroups {
+		if v["subscription_ids"].(*pluginsdk.Set).Len() > 0 || v["tenant_ids"].(*pluginsdk.Set).Len() > 0 {
+			return nil, fmt.Errorf("`subscription_ids` and `tenant_ids` can only be specified when `permission` is set to `%s`", compute.GallerySharingPermissionTypesGroups)
+		}
+	}
+
+	return &output, nil
+}
+
+func sharedImageGallerySharingGroupIds(input []interface{}, groupType compute.SharingProfileGroupTypes) *pluginsdk.Set {
+	output := pluginsdk.NewSet(pluginsdk.HashString, []interface{}{})
+	if len(input) == 0 || input[0] == nil {
+		return output
+	}
+
+	key := "subscription_ids"
+	if groupType == compute.SharingProfileGroupTypesAADTenants {
+		key = "tenant_ids"
+	}
+
+	for _, id := range input[0].(map[string]interface{})[key].(*pluginsdk.Set).List() {
+		output.Add(id)
+	}
+
+	return output
+}
+
+func flattenSharedImageGallerySharing(input *compute.SharingProfile) []interface{} {
+	if input == nil || input.Permissions == "" {
+		return []interface{}{}
+	}
+
+	communityGallery := make([]interface{}, 0)
+	if info := input.CommunityGalleryInfo; info != nil {
+		name := ""
+		if info.PublicNames != nil && len(*info.PublicNames) > 0 {
+			name = (*info.PublicNames)[0]
+		}
+
+		communityGallery = append(communityGallery, map[string]interface{}{
+			"eula":            utils.NormalizeNilableString(info.Eula),
+			"prefix":          utils.NormalizeNilableString(info.PublicNamePrefix),
+			"publisher_email": utils.NormalizeNilableString(info.PublisherContact),
+			"publisher_uri":   utils.NormalizeNilableString(info.PublisherURI),
+			"name":            name,
+		})
+	}
+
+	subscriptionIds := make([]interface{}, 0)
+	tenantIds := make([]interface{}, 0)
+	if input.Groups != nil {
+		for _, group := range *input.Groups {
+			if group.Ids == nil {
+				continue
+			}
+
+			for _, id := range *group.Ids {
+				switch group.Type {
+				case compute.SharingProfileGroupTypesSubscriptions:
+					subscriptionIds = append(subscriptionIds, id)
+				case compute.SharingProfileGroupTypesAADTenants:
+					tenantIds = append(tenantIds, id)
+				}
+			}
+		}
+	}
+
+	return []interface{}{
+		map[string]interface{}{
+			"permission":        string(input.Permissions),
+			"community_gallery": communityGallery,
+			"subscription_ids":  subscriptionIds,
+			"tenant_ids":        tenantIds,
+		},
+	}
+}
@@ -69,6 +69,96 @@ func TestAccSharedImageGallery_complete(t *testing.T) {
 	})
 }
 
+func TestAccSharedImageGallery_sharingCommunity(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_shared_image_gallery", "test")
+	r := SharedImageGalleryResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.sharingCommunity(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("sharing.0.permission").HasValue("Community"),
+				check.That(data.ResourceName).Key("sharing.0.community_gallery.0.name").Exists(),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func TestAccSharedImageGallery_sharingGroups(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_shared_image_gallery", "test")
+	r := SharedImageGalleryResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.sharingGroups(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("sharing.0.permission").HasValue("Groups"),
+				check.That(data.ResourceName).Key("sharing.0.subscription_ids.#").HasValue("1"),
+				check.That(data.ResourceName).Key("sharing.0.tenant_ids.#").HasValue("0"),
+			),
+		},
+		data.ImportStep(),
+		{
+			Config: r.sharingGroupsUpdated(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("sharing.0.subscription_ids.#").HasValue("0"),
+				check.That(data.ResourceName).Key("sharing.0.tenant_ids.#").HasValue("1"),
+			),
+		},
+		data.ImportStep(),
+		{
+			Config: r.sharingGroups(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func TestAccSharedImageGallery_sharingPermissionUpdate(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_shared_image_gallery", "test")
+	r := SharedImageGalleryResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(),
+		{
+			Config: r.sharingGroups(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("sharing.0.permission").HasValue("Groups"),
+			),
+		},
+		data.ImportStep(),
+		{
+			Config: r.sharingCommunity(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("sharing.0.permission").HasValue("Community"),
+				check.That(data.ResourceName).Key("sharing.0.community_gallery.0.name").Exists(),
+			),
+		},
+		data.ImportStep(),
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
 func (t SharedImageGalleryResource) Exists(ctx context.Context, clients *clients.Client, state *pluginsdk.InstanceState) (*bool, error) {
 	id, err := parse.SharedImageGalleryID(state.ID)
 	if err != nil {
@@ -138,3 +228,85 @@ resource "azurerm_shared_image_gallery" "test" {
 }
 `, data.RandomInteger, data.Locations.Primary, data.RandomInteger)
 }
+
+func (SharedImageGalleryResource) sharingCommunity(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+provider "azurerm" {
+  features {}
+}
+
+resource "azurerm_resource_group" "test" {
+  name     = "acctestRG-%[1]d"
+  location = "%[2]s"
+}
+
+resource "azurerm_shared_image_gallery" "test" {
+  name                = "acctestsig%[1]d"
+  resource_group_name = azurerm_resource_group.test.name
+  location            = azurerm_resource_group.test.location
+
+  sharing {
+    permission = "Community"
+
+    community_gallery {
+      eula            = "https://eula.example.com"
+      prefix          = "prefix"
+      publisher_email = "publisher@example.com"
+      publisher_uri   = "https://publisher.example.com"
+    }
+  }
+}
+`, data.RandomInteger, data.Locations.Primary)
+}
+
+func (SharedImageGalleryResource) sharingGroups(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+provider "azurerm" {
+  features {}
+}
+
+data "azurerm_client_config" "current" {}
+
+resource "azurerm_resource_group" "test" {
+  name     = "acctestRG-%[1]d"
+  location = "%[2]s"
+}
+
+resource "azurerm_shared_image_gallery" "test" {
+  name                = "acctestsig%[1]d"
+  resource_group_name = azurerm_resource_group.test.name
+  location            = azurerm_resource_group.test.location
+
+  sharing {
+    permission       = "Groups"
+    subscription_ids = [data.azurerm_client_config.current.subscription_id]
+  }
+}
+`, data.RandomInteger, data.Locations.Primary)
+}
+
+func (SharedImageGalleryResource) sharingGroupsUpdated(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+provider "azurerm" {
+  features {}
+}
+
+data "azurerm_client_config" "current" {}
+
+resource "azurerm_resource_group" "test" {
+  name     = "acctestRG-%[1]d"
+  location = "%[2]s"
+}
+
+resource "azurerm_shared_image_gallery" "test" {
+  name                = "acctestsig%[1]d"
+  resource_group_name = azurerm_resource_group.test.name
+  location            = azurerm_resource_group.test.location
+
+  sharing {
+    permission = "Groups"
+    tenant_ids = [data.azurerm_client_config.current.tenant_id]
+  }
+}
+`, data.RandomInteger, data.Locations.Primary)
+}
@@ -23,6 +23,12 @@ func virtualMachineAdditionalCapabilitiesSchema() *pluginsdk.Schema {
 		MaxItems: 1,
 		Elem: &pluginsdk.Resource{
 			Schema: map[string]*pluginsdk.Schema{
+				"hibernation_enabled": {
+					Type:     pluginsdk.TypeBool,
+					Optional: true,
+					Default:  false,
+				},
+
 				// TODO: confirm this command
 
 				// NOTE: requires registration to use:
@@ -44,6 +50,7 @@ func expandVirtualMachineAdditionalCapabilities(input []interface{}) *compute.Ad
 	if len(input) > 0 {
 		raw := input[0].(map[string]interface{})
 
+		capabilities.HibernationEnabled = utils.Bool(raw["hibernation_enabled"].(bool))
 		capabilities.UltraSSDEnabled = utils.Bool(raw["ultra_ssd_enabled"].(bool))
 	}
 
@@ -55,6 +62,11 @@ func flattenVirtualMachineAdditionalCapabilities(input *compute.AdditionalCapabi
 		return []interface{}{}
 	}
 
+	hibernationEnabled := false
+	if input.HibernationEnabled != nil {
+		hibernationEnabled = *input.HibernationEnabled
+	}
+
 	ultraSsdEnabled := false
 
 	if input.UltraSSDEnabled != nil {
@@ -63,7 +75,8 @@ func flattenVirtualMachineAdditionalCapabilities(input *compute.AdditionalCapabi
 
 	return []interface{}{
 		map[string]interface{}{
-			"ultra_ssd_enabled": ultraSsdEnabled,
+			"hibernation_enabled": hibernationEnabled,
+			"ultra_ssd_enabled":   ultraSsdEnabled,
 		},
 	}
 }
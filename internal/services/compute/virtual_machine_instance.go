@@ -1,11 +1,32 @@
 package compute
 
 import (
+	"context"
+	"fmt"
+	"log"
 	"strings"
+	"time"
 
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/compute/parse"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/validation"
+	"github.com/hashicorp/terraform-provider-azurerm/utils"
 	"github.com/tombuildsstuff/kermit/sdk/compute/2022-08-01/compute"
 )
 
+const (
+	virtualMachinePowerStateDeallocated = "deallocated"
+	virtualMachinePowerStateHibernated  = "hibernated"
+	virtualMachinePowerStateRunning     = "running"
+	virtualMachinePowerStateStopped     = "stopped"
+
+	// transitional Power States, which the Virtual Machine is moving out of
+	virtualMachinePowerStateDeallocating = "deallocating"
+	virtualMachinePowerStateStarting     = "starting"
+	virtualMachinePowerStateStopping     = "stopping"
+	virtualMachinePowerStateUnknown      = "unknown"
+)
+
 // virtualMachineShouldBeStarted determines if the Virtual Machine should be started after
 // the Virtual Machine has been shut down for maintenance. This means that Virtual Machines
 // which are already stopped can be updated but will not be started
@@ -32,3 +53,165 @@ func virtualMachineShouldBeStarted(instanceView compute.VirtualMachineInstanceVi
 
 	return false
 }
+
+func virtualMachinePowerStateSchema() *pluginsdk.Schema {
+	return &pluginsdk.Schema{
+		Type:     pluginsdk.TypeString,
+		Optional: true,
+		Computed: true,
+		ValidateFunc: validation.StringInSlice([]string{
+			virtualMachinePowerStateDeallocated,
+			virtualMachinePowerStateHibernated,
+			virtualMachinePowerStateRunning,
+			virtualMachinePowerStateStopped,
+		}, false),
+	}
+}
+
+// virtualMachinePowerState returns the current Power State of the Virtual Machine from the Instance View.
+// A hibernated Virtual Machine reports a Power State of `deallocated` alongside a Hibernation State,
+// so this is surfaced as `hibernated` to allow it to be distinguished from a regular deallocation
+func virtualMachinePowerState(instanceView compute.VirtualMachineInstanceView) string {
+	powerState := ""
+	hibernated := false
+
+	if instanceView.Statuses != nil {
+		for _, status := range *instanceView.Statuses {
+			if status.Code == nil {
+				continue
+			}
+
+			state := strings.ToLower(*status.Code)
+			if strings.HasPrefix(state, "powerstate/") {
+				powerState = strings.TrimPrefix(state, "powerstate/")
+			}
+			if state == "hibernationstate/hibernated" {
+				hibernated = true
+			}
+		}
+	}
+
+	if hibernated && powerState == virtualMachinePowerStateDeallocated {
+		return virtualMachinePowerStateHibernated
+	}
+
+	return powerState
+}
+
+// virtualMachinePowerStateCustomizeDiff ensures that hibernation is enabled when the Virtual Machine should be
+// hibernated, so that this is caught at plan time rather than once the Virtual Machine has been created or updated
+func virtualMachinePowerStateCustomizeDiff(ctx context.Context, d *pluginsdk.ResourceDiff, meta interface{}) error {
+	if !d.NewValueKnown("power_state") || !d.NewValueKnown("additional_capabilities") {
+		return nil
+	}
+
+	if d.Get("power_state").(string) == virtualMachinePowerStateHibernated && !d.Get("additional_capabilities.0.hibernation_enabled").(bool) {
+		return fmt.Errorf("`additional_capabilities.0.hibernation_enabled` must be set to `true` when `power_state` is set to `hibernated`")
+	}
+
+	return nil
+}
+
+// waitForVirtualMachineStablePowerState waits for the Virtual Machine to finish any Power State transition which is
+// in progress (for example a shutdown which was triggered from within the guest), returning the resulting Power State
+func waitForVirtualMachineStablePowerState(ctx context.Context, client *compute.VirtualMachinesClient, id parse.VirtualMachineId) (string, error) {
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		return "", fmt.Errorf("internal-error: context had no deadline")
+	}
+
+	stateConf := &pluginsdk.StateChangeConf{
+		Pending: []string{
+			virtualMachinePowerStateDeallocating,
+			virtualMachinePowerStateStarting,
+			virtualMachinePowerStateStopping,
+			virtualMachinePowerStateUnknown,
+			"",
+		},
+		Target: []string{
+			virtualMachinePowerStateDeallocated,
+			virtualMachinePowerStateHibernated,
+			virtualMachinePowerStateRunning,
+			virtualMachinePowerStateStopped,
+		},
+		Refresh: func() (interface{}, string, error) {
+			instanceView, err := client.InstanceView(ctx, id.ResourceGroup, id.Name)
+			if err != nil {
+				return nil, "", fmt.Errorf("retrieving InstanceView for %s: %+v", id, err)
+			}
+
+			return instanceView, virtualMachinePowerState(instanceView), nil
+		},
+		MinTimeout: 10 * time.Second,
+		Timeout:    time.Until(deadline),
+	}
+
+	result, err := stateConf.WaitForStateContext(ctx)
+	if err != nil {
+		return "", fmt.Errorf("waiting for the Power State of %s to become stable: %+v", id, err)
+	}
+
+	return virtualMachinePowerState(result.(compute.VirtualMachineInstanceView)), nil
+}
+
+// setVirtualMachinePowerState transitions the Virtual Machine from its current Power State into the desired one,
+// waiting for any transition which is already in progress to complete before deciding how to reach the desired one
+func setVirtualMachinePowerState(ctx context.Context, client *compute.VirtualMachinesClient, id parse.VirtualMachineId, desiredState string) error {
+	currentState, err := waitForVirtualMachineStablePowerState(ctx, client, id)
+	if err != nil {
+		return err
+	}
+
+	if strings.EqualFold(currentState, desiredState) {
+		return nil
+	}
+
+	// a Virtual Machine can only be powered off or hibernated whilst it's running, and a hibernated
+	// Virtual Machine has to be resumed before it can be deallocated, so it's started first
+	startFirst := false
+	switch desiredState {
+	case virtualMachinePowerStateStopped, virtualMachinePowerStateHibernated:
+		startFirst = currentState != virtualMachinePowerStateRunning
+	case virtualMachinePowerStateDeallocated:
+		startFirst = currentState == virtualMachinePowerStateHibernated
+	}
+
+	if desiredState == virtualMachinePowerStateRunning || startFirst {
+		log.Printf("[DEBUG] Starting %s..", id)
+		future, err := client.Start(ctx, id.ResourceGroup, id.Name)
+		if err != nil {
+			return fmt.Errorf("starting %s: %+v", id, err)
+		}
+		if err := future.WaitForCompletionRef(ctx, client.Client); err != nil {
+			return fmt.Errorf("waiting for start of %s: %+v", id, err)
+		}
+		log.Printf("[DEBUG] Started %s.", id)
+	}
+
+	switch desiredState {
+	case virtualMachinePowerStateStopped:
+		log.Printf("[DEBUG] Powering Off %s..", id)
+		future, err := client.PowerOff(ctx, id.ResourceGroup, id.Name, utils.Bool(false))
+		if err != nil {
+			return fmt.Errorf("powering off %s: %+v", id, err)
+		}
+		if err := future.WaitForCompletionRef(ctx, client.Client); err != nil {
+			return fmt.Errorf("waiting for power off of %s: %+v", id, err)
+		}
+		log.Printf("[DEBUG] Powered Off %s.", id)
+
+	case virtualMachinePowerStateDeallocated, virtualMachinePowerStateHibernated:
+		hibernate := desiredState == virtualMachinePowerStateHibernated
+		log.Printf("[DEBUG] Deallocating %s (hibernate: %t)..", id, hibernate)
+		future, err := client.Deallocate(ctx, id.ResourceGroup, id.Name, utils.Bool(hibernate))
+		if err != nil {
+			return fmt.Errorf("deallocating %s: %+v", id, err)
+		}
+		if err := future.WaitForCompletionRef(ctx, client.Client); err != nil {
+			return fmt.Errorf("waiting for deallocation of %s: %+v", id, err)
+		}
+		log.Printf("[DEBUG] Deallocated %s.", id)
+	}
+
+	return nil
+}
@@ -0,0 +1,506 @@
+package compute
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonschema"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/location"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/compute/parse"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/compute/validate"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tags"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/validation"
+	"github.com/hashicorp/terraform-provider-azurerm/utils"
+	"github.com/tombuildsstuff/kermit/sdk/compute/2022-08-01/compute"
+)
+
+type VirtualMachineRunCommandResource struct{}
+
+var _ sdk.ResourceWithUpdate = VirtualMachineRunCommandResource{}
+
+type VirtualMachineRunCommandModel struct {
+	Name                string                                 `tfschema:"name"`
+	VirtualMachineId    string                                 `tfschema:"virtual_machine_id"`
+	Location            string                                 `tfschema:"location"`
+	Source              []VirtualMachineRunCommandSource       `tfschema:"source"`
+	Parameters          []VirtualMachineRunCommandParameter    `tfschema:"parameter"`
+	ProtectedParameters []VirtualMachineRunCommandParameter    `tfschema:"protected_parameter"`
+	RunAsUser           string                                 `tfschema:"run_as_user"`
+	RunAsPassword       string                                 `tfschema:"run_as_password"`
+	OutputBlobUri       string                                 `tfschema:"output_blob_uri"`
+	ErrorBlobUri        string                                 `tfschema:"error_blob_uri"`
+	InstanceView        []VirtualMachineRunCommandInstanceView `tfschema:"instance_view"`
+	Tags                map[string]string                      `tfschema:"tags"`
+}
+
+type VirtualMachineRunCommandSource struct {
+	CommandId string `tfschema:"command_id"`
+	Script    string `tfschema:"script"`
+	ScriptUri string `tfschema:"script_uri"`
+}
+
+type VirtualMachineRunCommandParameter struct {
+	Name  string `tfschema:"name"`
+	Value string `tfschema:"value"`
+}
+
+type VirtualMachineRunCommandInstanceView struct {
+	EndTime          string `tfschema:"end_time"`
+	ErrorMessage     string `tfschema:"error_message"`
+	ExecutionMessage string `tfschema:"execution_message"`
+	ExecutionState   string `tfschema:"execution_state"`
+	ExitCode         int    `tfschema:"exit_code"`
+	Output           string `tfschema:"output"`
+	StartTime        string `tfschema:"start_time"`
+}
+
+func (r VirtualMachineRunCommandResource) Arguments() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"name": {
+			Type:         pluginsdk.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: validation.StringIsNotEmpty,
+		},
+
+		"virtual_machine_id": {
+			Type:         pluginsdk.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: validate.VirtualMachineID,
+		},
+
+		"location": commonschema.Location(),
+
+		"source": {
+			Type:     pluginsdk.TypeList,
+			Required: true,
+			MaxItems: 1,
+			Elem: &pluginsdk.Resource{
+				Schema: map[string]*pluginsdk.Schema{
+					"command_id": {
+						Type:         pluginsdk.TypeString,
+						Optional:     true,
+						ValidateFunc: validation.StringIsNotEmpty,
+						ExactlyOneOf: []string{"source.0.command_id", "source.0.script", "source.0.script_uri"},
+					},
+
+					"script": {
+						Type:         pluginsdk.TypeString,
+						Optional:     true,
+						ValidateFunc: validation.StringIsNotEmpty,
+						ExactlyOneOf: []string{"source.0.command_id", "source.0.script", "source.0.script_uri"},
+					},
+
+					"script_uri": {
+						Type:         pluginsdk.TypeString,
+						Optional:     true,
+						ValidateFunc: validation.IsURLWithHTTPorHTTPS,
+						ExactlyOneOf: []string{"source.0.command_id", "source.0.script", "source.0.script_uri"},
+					},
+				},
+			},
+		},
+
+		"parameter": {
+			Type:     pluginsdk.TypeList,
+			Optional: true,
+			Elem: &pluginsdk.Resource{
+				Schema: map[string]*pluginsdk.Schema{
+					"name": {
+						Type:         pluginsdk.TypeString,
+						Required:     true,
+						ValidateFunc: validation.StringIsNotEmpty,
+					},
+
+					"value": {
+						Type:         pluginsdk.TypeString,
+						Required:     true,
+						ValidateFunc: validation.StringIsNotEmpty,
+					},
+				},
+			},
+		},
+
+		"protected_parameter": {
+			Type:      pluginsdk.TypeList,
+			Optional:  true,
+			Sensitive: true,
+			Elem: &pluginsdk.Resource{
+				Schema: map[string]*pluginsdk.Schema{
+					"name": {
+						Type:         pluginsdk.TypeString,
+						Required:     true,
+						ValidateFunc: validation.StringIsNotEmpty,
+					},
+
+					"value": {
+						Type:         pluginsdk.TypeString,
+						Required:     true,
+						Sensitive:    true,
+						ValidateFunc: validation.StringIsNotEmpty,
+					},
+				},
+			},
+		},
+
+		"run_as_user": {
+			Type:         pluginsdk.TypeString,
+			Optional:     true,
+			ValidateFunc: validation.StringIsNotEmpty,
+		},
+
+		"run_as_password": {
+			Type:         pluginsdk.TypeString,
+			Optional:     true,
+			Sensitive:    true,
+			ValidateFunc: validation.StringIsNotEmpty,
+			RequiredWith: []string{"run_as_user"},
+		},
+
+		"output_blob_uri": {
+			Type:         pluginsdk.TypeString,
+			Optional:     true,
+			ValidateFunc: validation.IsURLWithHTTPS,
+		},
+
+		"error_blob_uri": {
+			Type:         pluginsdk.TypeString,
+			Optional:     true,
+			ValidateFunc: validation.IsURLWithHTTPS,
+		},
+
+		"tags": tags.Schema(),
+	}
+}
+
+func (r VirtualMachineRunCommandResource) Attributes() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"instance_view": {
+			Type:     pluginsdk.TypeList,
+			Computed: true,
+			Elem: &pluginsdk.Resource{
+				Schema: map[string]*pluginsdk.Schema{
+					"end_time": {
+						Type:     pluginsdk.TypeString,
+						Computed: true,
+					},
+
+					"error_message": {
+						Type:     pluginsdk.TypeString,
+						Computed: true,
+					},
+
+					"execution_message": {
+						Type:     pluginsdk.TypeString,
+						Computed: true,
+					},
+
+					"execution_state": {
+						Type:     pluginsdk.TypeString,
+						Computed: true,
+					},
+
+					"exit_code": {
+						Type:     pluginsdk.TypeInt,
+						Computed: true,
+					},
+
+					"output": {
+						Type:     pluginsdk.TypeString,
+						Computed: true,
+					},
+
+					"start_time": {
+						Type:     pluginsdk.TypeString,
+						Computed: true,
+					},
+				},
+			},
+		},
+	}
+}
+
+func (r VirtualMachineRunCommandResource) ResourceType() string {
+	return "azurerm_virtual_machine_run_command"
+}
+
+func (r VirtualMachineRunCommandResource) ModelObject() interface{} {
+	return &VirtualMachineRunCommandModel{}
+}
+
+func (r VirtualMachineRunCommandResource) IDValidationFunc() pluginsdk.SchemaValidateFunc {
+	return validate.VirtualMachineRunCommandID
+}
+
+func (r VirtualMachineRunCommandResource) Create() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 90 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.Compute.VirtualMachineRunCommandsClient
+
+			var state VirtualMachineRunCommandModel
+			if err := metadata.Decode(&state); err != nil {
+				return err
+			}
+
+			virtualMachineId, err := parse.VirtualMachineID(state.VirtualMachineId)
+			if err != nil {
+				return err
+			}
+
+			id := parse.NewVirtualMachineRunCommandID(virtualMachineId.SubscriptionId, virtualMachineId.ResourceGroup, virtualMachineId.Name, state.Name)
+
+			existing, err := client.GetByVirtualMachine(ctx, id.ResourceGroup, id.VirtualMachineName, id.RunCommandName, "")
+			if err != nil && !utils.ResponseWasNotFound(existing.Response) {
+				return fmt.Errorf("checking for the presence of existing %s: %+v", id, err)
+			}
+			if !utils.ResponseWasNotFound(existing.Response) {
+				return metadata.ResourceRequiresImport(r.ResourceType(), id)
+			}
+
+			input := compute.VirtualMachineRunCommand{
+				Location:                           utils.String(location.Normalize(state.Location)),
+				VirtualMachineRunCommandProperties: expandVirtualMachineRunCommandProperties(state),
+				Tags:                               tags.FromTypedObject(state.Tags),
+			}
+
+			future, err := client.CreateOrUpdate(ctx, id.ResourceGroup, id.VirtualMachineName, id.RunCommandName, input)
+			if err != nil {
+				return fmt.Errorf("creating %s: %+v", id, err)
+			}
+
+			if err := future.WaitForCompletionRef(ctx, client.Client); err != nil {
+				return fmt.Errorf("waiting for creation of %s: %+v", id, err)
+			}
+
+			metadata.SetID(id)
+			return nil
+		},
+	}
+}
+
+func (r VirtualMachineRunCommandResource) Read() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 5 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.Compute.VirtualMachineRunCommandsClient
+
+			id, err := parse.VirtualMachineRunCommandID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			resp, err := client.GetByVirtualMachine(ctx, id.ResourceGroup, id.VirtualMachineName, id.RunCommandName, "instanceView")
+			if err != nil {
+				if utils.ResponseWasNotFound(resp.Response) {
+					return metadata.MarkAsGone(id)
+				}
+
+				return fmt.Errorf("retrieving %s: %+v", *id, err)
+			}
+
+			// the protected parameters, the password and the blob URIs (which contain a SAS Token) aren't returned
+			// by the API, so these are retrieved from the state
+			var config VirtualMachineRunCommandModel
+			if err := metadata.Decode(&config); err != nil {
+				return err
+			}
+
+			state := VirtualMachineRunCommandModel{
+				Name:                id.RunCommandName,
+				VirtualMachineId:    parse.NewVirtualMachineID(id.SubscriptionId, id.ResourceGroup, id.VirtualMachineName).ID(),
+				Location:            location.NormalizeNilable(resp.Location),
+				ProtectedParameters: config.ProtectedParameters,
+				RunAsPassword:       config.RunAsPassword,
+				OutputBlobUri:       config.OutputBlobUri,
+				ErrorBlobUri:        config.ErrorBlobUri,
+				Tags:                tags.ToTypedObject(resp.Tags),
+			}
+
+			if props := resp.VirtualMachineRunCommandProperties; props != nil {
+				state.Source = flattenVirtualMachineRunCommandSource(props.Source)
+				state.Parameters = flattenVirtualMachineRunCommandParameters(props.Parameters)
+				state.RunAsUser = utils.NormalizeNilableString(props.RunAsUser)
+				state.InstanceView = flattenVirtualMachineRunCommandInstanceView(props.InstanceView)
+			}
+
+			return metadata.Encode(&state)
+		},
+	}
+}
+
+func (r VirtualMachineRunCommandResource) Update() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 90 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.Compute.VirtualMachineRunCommandsClient
+
+			id, err := parse.VirtualMachineRunCommandID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			var state VirtualMachineRunCommandModel
+			if err := metadata.Decode(&state); err != nil {
+				return err
+			}
+
+			// the protected parameters and the password aren't returned by the API, as such the full payload is sent
+			// which (as with the creation) runs the command again
+			input := compute.VirtualMachineRunCommand{
+				Location:                           utils.String(location.Normalize(state.Location)),
+				VirtualMachineRunCommandProperties: expandVirtualMachineRunCommandProperties(state),
+				Tags:                               tags.FromTypedObject(state.Tags),
+			}
+
+			future, err := client.CreateOrUpdate(ctx, id.ResourceGroup, id.VirtualMachineName, id.RunCommandName, input)
+			if err != nil {
+				return fmt.Errorf("updating %s: %+v", *id, err)
+			}
+
+			if err := future.WaitForCompletionRef(ctx, client.Client); err != nil {
+				return fmt.Errorf("waiting for update of %s: %+v", *id, err)
+			}
+
+			return nil
+		},
+	}
+}
+
+func (r VirtualMachineRunCommandResource) Delete() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 30 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.Compute.VirtualMachineRunCommandsClient
+
+			id, err := parse.VirtualMachineRunCommandID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			future, err := client.Delete(ctx, id.ResourceGroup, id.VirtualMachineName, id.RunCommandName)
+			if err != nil {
+				return fmt.Errorf("deleting %s: %+v", *id, err)
+			}
+
+			if err := future.WaitForCompletionRef(ctx, client.Client); err != nil {
+				return fmt.Errorf("waiting for deletion of %s: %+v", *id, err)
+			}
+
+			return nil
+		},
+	}
+}
+
+func expandVirtualMachineRunCommandProperties(input VirtualMachineRunCommandModel) *compute.VirtualMachineRunCommandProperties {
+	props := &compute.VirtualMachineRunCommandProperties{
+		AsyncExecution:      utils.Bool(false),
+		Parameters:          expandVirtualMachineRunCommandParameters(input.Parameters),
+		ProtectedParameters: expandVirtualMachineRunCommandParameters(input.ProtectedParameters),
+	}
+
+	if len(input.Source) > 0 {
+		source := input.Source[0]
+		props.Source = &compute.VirtualMachineRunCommandScriptSource{}
+		if source.CommandId != "" {
+			props.Source.CommandID = utils.String(source.CommandId)
+		}
+		if source.Script != "" {
+			props.Source.Script = utils.String(source.Script)
+		}
+		if source.ScriptUri != "" {
+			props.Source.ScriptURI = utils.String(source.ScriptUri)
+		}
+	}
+
+	if input.RunAsUser != "" {
+		props.RunAsUser = utils.String(input.RunAsUser)
+	}
+
+	if input.RunAsPassword != "" {
+		props.RunAsPassword = utils.String(input.RunAsPassword)
+	}
+
+	if input.OutputBlobUri != "" {
+		props.OutputBlobURI = utils.String(input.OutputBlobUri)
+	}
+
+	if input.ErrorBlobUri != "" {
+		props.ErrorBlobURI = utils.String(input.ErrorBlobUri)
+	}
+
+	return props
+}
+
+func expandVirtualMachineRunCommandParameters(input []VirtualMachineRunCommandParameter) *[]compute.RunCommandInputParameter {
+	result := make([]compute.RunCommandInputParameter, 0)
+	for _, v := range input {
+		result = append(result, compute.RunCommandInputParameter{
+			Name:  utils.String(v.Name),
+			Value: utils.String(v.Value),
+		})
+	}
+
+	return &result
+}
+
+func flattenVirtualMachineRunCommandSource(input *compute.VirtualMachineRunCommandScriptSource) []VirtualMachineRunCommandSource {
+	if input == nil {
+		return []VirtualMachineRunCommandSource{}
+	}
+
+	return []VirtualMachineRunCommandSource{
+		{
+			CommandId: utils.NormalizeNilableString(input.CommandID),
+			Script:    utils.NormalizeNilableString(input.Script),
+			ScriptUri: utils.NormalizeNilableString(input.ScriptURI),
+		},
+	}
+}
+
+func flattenVirtualMachineRunCommandParameters(input *[]compute.RunCommandInputParameter) []VirtualMachineRunCommandParameter {
+	result := make([]VirtualMachineRunCommandParameter, 0)
+	if input == nil {
+		return result
+	}
+
+	for _, v := range *input {
+		result = append(result, VirtualMachineRunCommandParameter{
+			Name:  utils.NormalizeNilableString(v.Name),
+			Value: utils.NormalizeNilableString(v.Value),
+		})
+	}
+
+	return result
+}
+
+func flattenVirtualMachineRunCommandInstanceView(input *compute.VirtualMachineRunCommandInstanceView) []VirtualMachineRunCommandInstanceView {
+	if input == nil {
+		return []VirtualMachineRunCommandInstanceView{}
+	}
+
+	result := VirtualMachineRunCommandInstanceView{
+		ErrorMessage:     utils.NormalizeNilableString(input.Error),
+		ExecutionMessage: utils.NormalizeNilableString(input.ExecutionMessage),
+		ExecutionState:   string(input.ExecutionState),
+		Output:           utils.NormalizeNilableString(input.Output),
+	}
+
+	if input.ExitCode != nil {
+		result.ExitCode = int(*input.ExitCode)
+	}
+
+	if input.StartTime != nil {
+		result.StartTime = input.StartTime.Format(time.RFC3339)
+	}
+
+	if input.EndTime != nil {
+		result.EndTime = input.EndTime.Format(time.RFC3339)
+	}
+
+	return []VirtualMachineRunCommandInstanceView{result}
+}
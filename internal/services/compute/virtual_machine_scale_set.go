@@ -3,6 +3,7 @@ package compute
 import (
 	"bytes"
 	"fmt"
+	"time"
 
 	identity "github.com/hashicorp/go-azure-helpers/resourcemanager/identity"
 	"github.com/hashicorp/terraform-provider-azurerm/helpers/azure"
@@ -1892,6 +1893,119 @@ func FlattenVirtualMachineScaleSetRollingUpgradePolicy(input *compute.RollingUpg
 	}
 }
 
+func VirtualMachineScaleSetLastRollingUpgradeStatusSchema() *pluginsdk.Schema {
+	return &pluginsdk.Schema{
+		Type:     pluginsdk.TypeList,
+		Computed: true,
+		Elem: &pluginsdk.Resource{
+			Schema: map[string]*pluginsdk.Schema{
+				"status": {
+					Type:     pluginsdk.TypeString,
+					Computed: true,
+				},
+				"start_time": {
+					Type:     pluginsdk.TypeString,
+					Computed: true,
+				},
+				"last_action": {
+					Type:     pluginsdk.TypeString,
+					Computed: true,
+				},
+				"last_action_time": {
+					Type:     pluginsdk.TypeString,
+					Computed: true,
+				},
+				"successful_instance_count": {
+					Type:     pluginsdk.TypeInt,
+					Computed: true,
+				},
+				"failed_instance_count": {
+					Type:     pluginsdk.TypeInt,
+					Computed: true,
+				},
+				"in_progress_instance_count": {
+					Type:     pluginsdk.TypeInt,
+					Computed: true,
+				},
+				"pending_instance_count": {
+					Type:     pluginsdk.TypeInt,
+					Computed: true,
+				},
+				"error_message": {
+					Type:     pluginsdk.TypeString,
+					Computed: true,
+				},
+			},
+		},
+	}
+}
+
+func FlattenVirtualMachineScaleSetLastRollingUpgradeStatus(input *compute.RollingUpgradeStatusInfo) []interface{} {
+	if input == nil || input.RollingUpgradeStatusInfoProperties == nil {
+		return []interface{}{}
+	}
+
+	props := input.RollingUpgradeStatusInfoProperties
+
+	status := ""
+	startTime := ""
+	lastAction := ""
+	lastActionTime := ""
+	if runningStatus := props.RunningStatus; runningStatus != nil {
+		status = string(runningStatus.Code)
+		lastAction = string(runningStatus.LastAction)
+
+		if runningStatus.StartTime != nil {
+			startTime = runningStatus.StartTime.Format(time.RFC3339)
+		}
+
+		if runningStatus.LastActionTime != nil {
+			lastActionTime = runningStatus.LastActionTime.Format(time.RFC3339)
+		}
+	}
+
+	successfulInstanceCount := 0
+	failedInstanceCount := 0
+	inProgressInstanceCount := 0
+	pendingInstanceCount := 0
+	if progress := props.Progress; progress != nil {
+		if progress.SuccessfulInstanceCount != nil {
+			successfulInstanceCount = int(*progress.SuccessfulInstanceCount)
+		}
+
+		if progress.FailedInstanceCount != nil {
+			failedInstanceCount = int(*progress.FailedInstanceCount)
+		}
+
+		if progress.InProgressInstanceCount != nil {
+			inProgressInstanceCount = int(*progress.InProgressInstanceCount)
+		}
+
+		if progress.PendingInstanceCount != nil {
+			pendingInstanceCount = int(*progress.PendingInstanceCount)
+		}
+	}
+
+	errorMessage := ""
+	if props.Error != nil && props.Error.Message != nil {
+		errorMessage = *props.Error.Message
+	}
+
+	return []interface{}{
+		map[string]interface{}{
+			"status":                     status,
+			"start_time":                 startTime,
+			"last_action":                lastAction,
+			"last_action_time":           lastActionTime,
+			"successful_instance_count":  successfulInstanceCount,
+			"failed_instance_count":      failedInstanceCount,
+			"in_progress_instance_count": inProgressInstanceCount,
+			"pending_instance_count":     pendingInstanceCount,
+			"error_message":              errorMessage,
+		},
+	}
+}
+
 // TODO remove VirtualMachineScaleSetTerminateNotificationSchema in 4.0
 func VirtualMachineScaleSetTerminateNotificationSchema() *pluginsdk.Schema {
 	return &pluginsdk.Schema{
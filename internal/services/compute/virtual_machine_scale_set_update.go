@@ -5,13 +5,19 @@ import (
 	"fmt"
 	"log"
 	"strings"
+	"time"
 
 	"github.com/hashicorp/terraform-provider-azurerm/internal/services/compute/client"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/services/compute/parse"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
 	"github.com/hashicorp/terraform-provider-azurerm/utils"
 	"github.com/tombuildsstuff/kermit/sdk/compute/2022-08-01/compute"
 )
 
+// rollingUpgradeStartGracePeriod is how long we'll wait for a Rolling Upgrade to start once the Scale Set has been
+// updated, whilst instances are still not using the latest model
+const rollingUpgradeStartGracePeriod = 10 * time.Minute
+
 type virtualMachineScaleSetUpdateMetaData struct {
 	// is "automaticOSUpgrade" enable in the upgradeProfile block
 	AutomaticOSUpgradeIsEnabled bool
@@ -22,6 +28,12 @@ type virtualMachineScaleSetUpdateMetaData struct {
 	// do we need to roll the instances in this scale set?
 	UpdateInstances bool
 
+	// has the user explicitly requested that all instances are upgraded to the latest model?
+	UpgradeAllInstances bool
+
+	// should we wait for any Rolling Upgrade triggered by this update to complete?
+	WaitForRollingUpgrade bool
+
 	Client   *client.Client
 	Existing compute.VirtualMachineScaleSet
 	ID       *parse.VirtualMachineScaleSetId
@@ -38,14 +50,34 @@ func (metadata virtualMachineScaleSetUpdateMetaData) performUpdate(ctx context.C
 		update.VirtualMachineScaleSetUpdateProperties.UpgradePolicy.AutomaticOSUpgradePolicy.EnableAutomaticOSUpgrade = utils.Bool(false)
 	}
 
+	upgradeMode := metadata.Existing.VirtualMachineScaleSetProperties.UpgradePolicy.Mode
+	if update.VirtualMachineScaleSetUpdateProperties != nil && update.VirtualMachineScaleSetUpdateProperties.UpgradePolicy != nil && update.VirtualMachineScaleSetUpdateProperties.UpgradePolicy.Mode != "" {
+		upgradeMode = update.VirtualMachineScaleSetUpdateProperties.UpgradePolicy.Mode
+	}
+	// upgrading all of the instances of a Rolling Scale Set is done by the Rolling Upgrade which the platform starts when
+	// the model changes, so that the batches and health thresholds in the `rolling_upgrade_policy` are honoured
+	waitForRollingUpgrade := upgradeMode == compute.UpgradeModeRolling && (metadata.WaitForRollingUpgrade || metadata.UpgradeAllInstances)
+
+	// the Rolling Upgrade API only exposes the latest upgrade, so we need to know when that started prior to updating
+	// the Scale Set, to be able to tell whether this update has triggered a new Rolling Upgrade
+	var previousRollingUpgradeStartTime *time.Time
+	if waitForRollingUpgrade {
+		startTime, err := metadata.latestRollingUpgradeStartTime(ctx)
+		if err != nil {
+			return err
+		}
+		previousRollingUpgradeStartTime = startTime
+	}
+
 	if err := metadata.updateVmss(ctx, update); err != nil {
 		return err
 	}
 
+	instancesUpgraded := false
+
 	// if we update the SKU, we also need to subsequently roll the instances using the `UpdateInstances` API
 	if metadata.UpdateInstances {
 		userWantsToRollInstances := metadata.CanRollInstancesWhenRequired
-		upgradeMode := metadata.Existing.VirtualMachineScaleSetProperties.UpgradePolicy.Mode
 
 		if userWantsToRollInstances {
 			// If the updated image version is not "latest" and upgrade mode is automatic then azure will roll the instances automatically.
@@ -54,16 +86,41 @@ func (metadata virtualMachineScaleSetUpdateMetaData) performUpdate(ctx context.C
 				if err := metadata.upgradeInstancesForAutomaticUpgradePolicy(ctx); err != nil {
 					return err
 				}
+				instancesUpgraded = true
 			}
 
 			if upgradeMode == compute.UpgradeModeManual {
 				if err := metadata.upgradeInstancesForManualUpgradePolicy(ctx); err != nil {
 					return err
 				}
+				instancesUpgraded = true
+			}
+		}
+	}
+
+	// the user has explicitly asked for all of the instances to be upgraded, so do so unless that's already happened above
+	// (or will happen via the Rolling Upgrade, which is waited for below)
+	if metadata.UpgradeAllInstances && !instancesUpgraded {
+		switch upgradeMode {
+		case compute.UpgradeModeManual:
+			if err := metadata.upgradeInstancesForManualUpgradePolicy(ctx); err != nil {
+				return err
+			}
+		case compute.UpgradeModeAutomatic:
+			// an OS Upgrade (via `StartOSUpgrade`) is only possible when the Scale Set uses the `latest` version of a
+			// Platform Image, so instead update all of the instances to the latest model directly
+			if err := metadata.upgradeAllInstancesToLatestModel(ctx); err != nil {
+				return err
 			}
 		}
 	}
 
+	if waitForRollingUpgrade {
+		if err := metadata.waitForRollingUpgrade(ctx, previousRollingUpgradeStartTime); err != nil {
+			return err
+		}
+	}
+
 	if metadata.AutomaticOSUpgradeIsEnabled {
 		// Virtual Machine Scale Sets with Automatic OS Upgrade enabled must have all VM instances upgraded to same
 		// Platform Image. Upgrade all VM instances to latest Virtual Machine Scale Set model while property
@@ -187,6 +244,186 @@ func (metadata virtualMachineScaleSetUpdateMetaData) upgradeInstancesForManualUp
 	return nil
 }
 
+func (metadata virtualMachineScaleSetUpdateMetaData) upgradeAllInstancesToLatestModel(ctx context.Context) error {
+	client := metadata.Client.VMScaleSetClient
+	id := metadata.ID
+
+	log.Printf("[DEBUG] Listing the VM Instances for %s Virtual Machine Scale Set %q (Resource Group %q)..", metadata.OSType, id.Name, id.ResourceGroup)
+	instances, err := metadata.Client.VMScaleSetVMsClient.ListComplete(ctx, id.ResourceGroup, id.Name, "", "", "")
+	if err != nil {
+		return fmt.Errorf("listing VM Instances for %s Virtual Machine Scale Set %q (Resource Group %q): %+v", metadata.OSType, id.Name, id.ResourceGroup, err)
+	}
+
+	instanceIds := make([]string, 0)
+	for instances.NotDone() {
+		if instanceId := instances.Value().InstanceID; instanceId != nil {
+			instanceIds = append(instanceIds, *instanceId)
+		}
+
+		if err := instances.NextWithContext(ctx); err != nil {
+			return fmt.Errorf("enumerating instances: %s", err)
+		}
+	}
+
+	if len(instanceIds) == 0 {
+		log.Printf("[DEBUG] %s Virtual Machine Scale Set %q (Resource Group %q) has no instances to update.", metadata.OSType, id.Name, id.ResourceGroup)
+		return nil
+	}
+
+	log.Printf("[DEBUG] Updating all %d instances of %s Virtual Machine Scale Set %q (Resource Group %q) to the Latest Configuration..", len(instanceIds), metadata.OSType, id.Name, id.ResourceGroup)
+	ids := compute.VirtualMachineScaleSetVMInstanceRequiredIDs{
+		InstanceIds: &instanceIds,
+	}
+	future, err := client.UpdateInstances(ctx, id.ResourceGroup, id.Name, ids)
+	if err != nil {
+		return fmt.Errorf("updating the instances of %s Virtual Machine Scale Set %q (Resource Group %q) to the Latest Configuration: %+v", metadata.OSType, id.Name, id.ResourceGroup, err)
+	}
+
+	if err = future.WaitForCompletionRef(ctx, client.Client); err != nil {
+		return fmt.Errorf("waiting for update of the instances of %s Virtual Machine Scale Set %q (Resource Group %q) to the Latest Configuration: %+v", metadata.OSType, id.Name, id.ResourceGroup, err)
+	}
+	log.Printf("[DEBUG] Updated all instances of %s Virtual Machine Scale Set %q (Resource Group %q) to the Latest Configuration.", metadata.OSType, id.Name, id.ResourceGroup)
+
+	return nil
+}
+
+func (metadata virtualMachineScaleSetUpdateMetaData) allInstancesUsingLatestModel(ctx context.Context) (bool, error) {
+	id := metadata.ID
+
+	instances, err := metadata.Client.VMScaleSetVMsClient.ListComplete(ctx, id.ResourceGroup, id.Name, "", "", "")
+	if err != nil {
+		return false, fmt.Errorf("listing VM Instances for %s Virtual Machine Scale Set %q (Resource Group %q): %+v", metadata.OSType, id.Name, id.ResourceGroup, err)
+	}
+
+	for instances.NotDone() {
+		if props := instances.Value().VirtualMachineScaleSetVMProperties; props != nil {
+			if props.LatestModelApplied == nil || !*props.LatestModelApplied {
+				return false, nil
+			}
+		}
+
+		if err := instances.NextWithContext(ctx); err != nil {
+			return false, fmt.Errorf("enumerating instances: %s", err)
+		}
+	}
+
+	return true, nil
+}
+
+func (metadata virtualMachineScaleSetUpdateMetaData) latestRollingUpgradeStartTime(ctx context.Context) (*time.Time, error) {
+	rollingUpgradesClient := metadata.Client.VMScaleSetRollingUpgradesClient
+	id := metadata.ID
+
+	resp, err := rollingUpgradesClient.GetLatest(ctx, id.ResourceGroup, id.Name)
+	if err != nil {
+		// a Scale Set which has never been upgraded has no latest Rolling Upgrade
+		if utils.ResponseWasNotFound(resp.Response) {
+			return nil, nil
+		}
+
+		return nil, fmt.Errorf("retrieving the latest Rolling Upgrade for %s Virtual Machine Scale Set %q (Resource Group %q): %+v", metadata.OSType, id.Name, id.ResourceGroup, err)
+	}
+
+	if props := resp.RollingUpgradeStatusInfoProperties; props != nil && props.RunningStatus != nil && props.RunningStatus.StartTime != nil {
+		return &props.RunningStatus.StartTime.Time, nil
+	}
+
+	return nil, nil
+}
+
+func (metadata virtualMachineScaleSetUpdateMetaData) waitForRollingUpgrade(ctx context.Context, previousStartTime *time.Time) error {
+	id := metadata.ID
+
+	timeout, _ := ctx.Deadline()
+	log.Printf("[DEBUG] Waiting for the Rolling Upgrade of %s Virtual Machine Scale Set %q (Resource Group %q) to complete..", metadata.OSType, id.Name, id.ResourceGroup)
+	stateConf := &pluginsdk.StateChangeConf{
+		Pending: []string{
+			string(compute.RollingUpgradeStatusCodeRollingForward),
+			"NotStarted",
+		},
+		Target: []string{
+			string(compute.RollingUpgradeStatusCodeCompleted),
+			"UpToDate",
+		},
+		Refresh:      metadata.rollingUpgradeStateRefreshFunc(ctx, previousStartTime),
+		Delay:        30 * time.Second,
+		PollInterval: 15 * time.Second,
+		Timeout:      time.Until(timeout),
+	}
+
+	if _, err := stateConf.WaitForStateContext(ctx); err != nil {
+		return fmt.Errorf("waiting for the Rolling Upgrade of %s Virtual Machine Scale Set %q (Resource Group %q) to complete: %+v", metadata.OSType, id.Name, id.ResourceGroup, err)
+	}
+	log.Printf("[DEBUG] Rolling Upgrade of %s Virtual Machine Scale Set %q (Resource Group %q) completed.", metadata.OSType, id.Name, id.ResourceGroup)
+
+	return nil
+}
+
+func (metadata virtualMachineScaleSetUpdateMetaData) rollingUpgradeStateRefreshFunc(ctx context.Context, previousStartTime *time.Time) pluginsdk.StateRefreshFunc {
+	rollingUpgradesClient := metadata.Client.VMScaleSetRollingUpgradesClient
+	id := metadata.ID
+
+	var notStartedSince *time.Time
+	notStarted := func(resp interface{}) (interface{}, string, error) {
+		// when every instance is already running the latest model there's nothing for a Rolling Upgrade to do,
+		// so this update can't have triggered one
+		upToDate, err := metadata.allInstancesUsingLatestModel(ctx)
+		if err != nil {
+			return nil, "", err
+		}
+		if upToDate {
+			return resp, "UpToDate", nil
+		}
+
+		// otherwise the Rolling Upgrade can take a few moments to start once the Scale Set has been updated
+		if notStartedSince == nil {
+			now := time.Now()
+			notStartedSince = &now
+		}
+		if time.Since(*notStartedSince) > rollingUpgradeStartGracePeriod {
+			return nil, "", fmt.Errorf("instances are not using the latest model but no Rolling Upgrade was started within %s", rollingUpgradeStartGracePeriod)
+		}
+
+		return resp, "NotStarted", nil
+	}
+
+	return func() (interface{}, string, error) {
+		resp, err := rollingUpgradesClient.GetLatest(ctx, id.ResourceGroup, id.Name)
+		if err != nil {
+			if utils.ResponseWasNotFound(resp.Response) {
+				return notStarted(resp)
+			}
+
+			return nil, "", fmt.Errorf("retrieving the latest Rolling Upgrade: %+v", err)
+		}
+
+		props := resp.RollingUpgradeStatusInfoProperties
+		if props == nil || props.RunningStatus == nil {
+			return nil, "", fmt.Errorf("retrieving the latest Rolling Upgrade: `properties.runningStatus` was nil")
+		}
+
+		// if the latest Rolling Upgrade predates this update then no Rolling Upgrade has been triggered (yet)
+		startTime := props.RunningStatus.StartTime
+		if startTime == nil || (previousStartTime != nil && !startTime.Time.After(*previousStartTime)) {
+			return notStarted(resp)
+		}
+
+		code := props.RunningStatus.Code
+		switch code {
+		case compute.RollingUpgradeStatusCodeFaulted, compute.RollingUpgradeStatusCodeCancelled:
+			// a Rolling Upgrade is Faulted when the `max_unhealthy_instance_percent` or
+			// `max_unhealthy_upgraded_instance_percent` thresholds in the `rolling_upgrade_policy` are breached
+			message := "no error details were returned"
+			if props.Error != nil && props.Error.Message != nil {
+				message = *props.Error.Message
+			}
+			return resp, string(code), fmt.Errorf("the Rolling Upgrade finished with the status %q: %s", string(code), message)
+		}
+
+		return resp, string(code), nil
+	}
+}
+
 func isUsingLatestImage(update compute.VirtualMachineScaleSetUpdate) bool {
 	if update.VirtualMachineProfile.StorageProfile == nil ||
 		update.VirtualMachineProfile.StorageProfile.ImageReference == nil ||
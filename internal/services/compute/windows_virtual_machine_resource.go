@@ -52,6 +52,8 @@ func resourceWindowsVirtualMachine() *pluginsdk.Resource {
 			Delete: pluginsdk.DefaultTimeout(45 * time.Minute),
 		},
 
+		CustomizeDiff: pluginsdk.CustomizeDiffShim(virtualMachinePowerStateCustomizeDiff),
+
 		Schema: map[string]*pluginsdk.Schema{
 			"name": {
 				Type:         pluginsdk.TypeString,
@@ -266,6 +268,8 @@ func resourceWindowsVirtualMachine() *pluginsdk.Resource {
 
 			"plan": planSchema(),
 
+			"power_state": virtualMachinePowerStateSchema(),
+
 			"priority": {
 				Type:     pluginsdk.TypeString,
 				Optional: true,
@@ -424,6 +428,8 @@ func resourceWindowsVirtualMachineCreate(d *pluginsdk.ResourceData, meta interfa
 		return tf.ImportAsExistsError("azurerm_windows_virtual_machine", *resp.ID)
 	}
 
+	powerState := d.Get("power_state").(string)
+
 	additionalCapabilitiesRaw := d.Get("additional_capabilities").([]interface{})
 	additionalCapabilities := expandVirtualMachineAdditionalCapabilities(additionalCapabilitiesRaw)
 
@@ -738,6 +744,14 @@ func resourceWindowsVirtualMachineCreate(d *pluginsdk.ResourceData, meta interfa
 	}
 
 	d.SetId(id.ID())
+
+	// the Virtual Machine is running once it's been provisioned, so this only transitions it into another Power State
+	if powerState != "" {
+		if err := setVirtualMachinePowerState(ctx, client, id, powerState); err != nil {
+			return err
+		}
+	}
+
 	return resourceWindowsVirtualMachineRead(d, meta)
 }
 
@@ -966,6 +980,12 @@ func resourceWindowsVirtualMachineRead(d *pluginsdk.ResourceData, meta interface
 
 	d.Set("virtual_machine_id", props.VMID)
 
+	instanceView, err := client.InstanceView(ctx, id.ResourceGroup, id.Name)
+	if err != nil {
+		return fmt.Errorf("retrieving InstanceView for Windows Virtual Machine %q (Resource Group %q): %+v", id.Name, id.ResourceGroup, err)
+	}
+	d.Set("power_state", virtualMachinePowerState(instanceView))
+
 	d.Set("user_data", props.UserData)
 
 	zone := ""
@@ -1331,6 +1351,12 @@ func resourceWindowsVirtualMachineUpdate(d *pluginsdk.ResourceData, meta interfa
 			shouldDeallocate = true
 		}
 
+		if d.HasChange("additional_capabilities.0.hibernation_enabled") {
+			// hibernation can only be enabled or disabled whilst the Virtual Machine is deallocated
+			shouldShutDown = true
+			shouldDeallocate = true
+		}
+
 		additionalCapabilitiesRaw := d.Get("additional_capabilities").([]interface{})
 		update.VirtualMachineProperties.AdditionalCapabilities = expandVirtualMachineAdditionalCapabilities(additionalCapabilitiesRaw)
 	}
@@ -1509,8 +1535,8 @@ func resourceWindowsVirtualMachineUpdate(d *pluginsdk.ResourceData, meta interfa
 		log.Printf("[DEBUG] Updated Windows Virtual Machine %q (Resource Group %q).", id.Name, id.ResourceGroup)
 	}
 
-	// if we've shut it down and it was turned off, let's boot it back up
-	if shouldTurnBackOn && (shouldShutDown || shouldDeallocate) {
+	// if we've shut it down and it was turned off, let's boot it back up - unless it's being transitioned into another power state below
+	if shouldTurnBackOn && (shouldShutDown || shouldDeallocate) && !d.HasChange("power_state") {
 		log.Printf("[DEBUG] Starting Windows Virtual Machine %q (Resource Group %q)..", id.Name, id.ResourceGroup)
 		future, err := client.Start(ctx, id.ResourceGroup, id.Name)
 		if err != nil {
@@ -1524,6 +1550,12 @@ func resourceWindowsVirtualMachineUpdate(d *pluginsdk.ResourceData, meta interfa
 		log.Printf("[DEBUG] Started Windows Virtual Machine %q (Resource Group %q)..", id.Name, id.ResourceGroup)
 	}
 
+	if d.HasChange("power_state") {
+		if err := setVirtualMachinePowerState(ctx, client, *id, d.Get("power_state").(string)); err != nil {
+			return err
+		}
+	}
+
 	return resourceWindowsVirtualMachineRead(d, meta)
 }
 
@@ -0,0 +1,290 @@
+package containerapps
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-helpers/lang/response"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/containerapps/2022-03-01/containerapps"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/containerapps/2022-03-01/managedenvironments"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/locks"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/containerapps/helpers"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/containerapps/parse"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/containerapps/validate"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/validation"
+)
+
+type ContainerAppCustomDomainResource struct{}
+
+type ContainerAppCustomDomainModel struct {
+	Name                                 string `tfschema:"name"`
+	ContainerAppId                       string `tfschema:"container_app_id"`
+	ContainerAppEnvironmentCertificateId string `tfschema:"container_app_environment_certificate_id"`
+	CertificateBindingType               string `tfschema:"certificate_binding_type"`
+	CustomDomainVerificationId           string `tfschema:"custom_domain_verification_id"`
+}
+
+var _ sdk.Resource = ContainerAppCustomDomainResource{}
+
+func (r ContainerAppCustomDomainResource) ModelObject() interface{} {
+	return &ContainerAppCustomDomainModel{}
+}
+
+func (r ContainerAppCustomDomainResource) ResourceType() string {
+	return "azurerm_container_app_custom_domain"
+}
+
+func (r ContainerAppCustomDomainResource) IDValidationFunc() pluginsdk.SchemaValidateFunc {
+	return validate.ContainerAppCustomDomainID
+}
+
+func (r ContainerAppCustomDomainResource) Arguments() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"name": {
+			Type:         pluginsdk.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: validation.StringIsNotEmpty,
+			Description:  "The hostname of the Custom Domain. Must be the CN or a named SAN in the certificate.",
+		},
+
+		"container_app_id": {
+			Type:         pluginsdk.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: containerapps.ValidateContainerAppID,
+			Description:  "The ID of the Container App to which this Custom Domain should be bound.",
+		},
+
+		"container_app_environment_certificate_id": {
+			Type:         pluginsdk.TypeString,
+			Optional:     true,
+			ForceNew:     true,
+			ValidateFunc: managedenvironments.ValidateCertificateID,
+			RequiredWith: []string{"certificate_binding_type"},
+			Description:  "The ID of the Container App Environment Certificate to use for this Custom Domain.",
+		},
+
+		"certificate_binding_type": {
+			Type:         pluginsdk.TypeString,
+			Optional:     true,
+			ForceNew:     true,
+			ValidateFunc: validation.StringInSlice(containerapps.PossibleValuesForBindingType(), false),
+			RequiredWith: []string{"container_app_environment_certificate_id"},
+			Description:  "The Binding type. Possible values include `Disabled` and `SniEnabled`.",
+		},
+	}
+}
+
+func (r ContainerAppCustomDomainResource) Attributes() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"custom_domain_verification_id": {
+			Type:        pluginsdk.TypeString,
+			Computed:    true,
+			Description: "The value of the `asuid` TXT record used to verify ownership of the Custom Domain.",
+		},
+	}
+}
+
+func (r ContainerAppCustomDomainResource) Create() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 30 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.ContainerApps.ContainerAppClient
+
+			var customDomain ContainerAppCustomDomainModel
+			if err := metadata.Decode(&customDomain); err != nil {
+				return err
+			}
+
+			containerAppId, err := containerapps.ParseContainerAppID(customDomain.ContainerAppId)
+			if err != nil {
+				return err
+			}
+
+			id := parse.NewContainerAppCustomDomainID(containerAppId.SubscriptionId, containerAppId.ResourceGroupName, containerAppId.ContainerAppName, customDomain.Name)
+
+			locks.ByID(containerAppId.ID())
+			defer locks.UnlockByID(containerAppId.ID())
+
+			model, err := containerAppForCustomDomainUpdate(ctx, client, *containerAppId)
+			if err != nil {
+				return err
+			}
+
+			ingress := model.Properties.Configuration.Ingress
+			if ingress == nil {
+				return fmt.Errorf("%s must have `ingress` configured before a Custom Domain can be bound", *containerAppId)
+			}
+
+			customDomains := make([]containerapps.CustomDomain, 0)
+			if ingress.CustomDomains != nil {
+				customDomains = *ingress.CustomDomains
+			}
+
+			for _, v := range customDomains {
+				if strings.EqualFold(v.Name, id.CustomDomainName) {
+					return metadata.ResourceRequiresImport(r.ResourceType(), id)
+				}
+			}
+
+			domain := containerapps.CustomDomain{
+				Name: id.CustomDomainName,
+			}
+			if customDomain.ContainerAppEnvironmentCertificateId != "" {
+				domain.CertificateId = pointer.To(customDomain.ContainerAppEnvironmentCertificateId)
+				domain.BindingType = pointer.To(containerapps.BindingType(customDomain.CertificateBindingType))
+			}
+
+			ingress.CustomDomains = pointer.To(append(customDomains, domain))
+
+			if err := client.CreateOrUpdateThenPoll(ctx, *containerAppId, *model); err != nil {
+				return fmt.Errorf("creating %s: %+v", id, err)
+			}
+
+			metadata.SetID(id)
+
+			return nil
+		},
+	}
+}
+
+func (r ContainerAppCustomDomainResource) Read() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 5 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.ContainerApps.ContainerAppClient
+
+			id, err := parse.ContainerAppCustomDomainID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			containerAppId := containerapps.NewContainerAppID(id.SubscriptionId, id.ResourceGroup, id.ContainerAppName)
+
+			existing, err := client.Get(ctx, containerAppId)
+			if err != nil {
+				if response.WasNotFound(existing.HttpResponse) {
+					return metadata.MarkAsGone(id)
+				}
+				return fmt.Errorf("reading %s: %+v", containerAppId, err)
+			}
+
+			var domain *containerapps.CustomDomain
+			var state ContainerAppCustomDomainModel
+
+			if model := existing.Model; model != nil {
+				if props := model.Properties; props != nil {
+					state.CustomDomainVerificationId = pointer.From(props.CustomDomainVerificationId)
+
+					if config := props.Configuration; config != nil && config.Ingress != nil && config.Ingress.CustomDomains != nil {
+						for _, v := range *config.Ingress.CustomDomains {
+							if strings.EqualFold(v.Name, id.CustomDomainName) {
+								domain = pointer.To(v)
+								break
+							}
+						}
+					}
+				}
+			}
+
+			if domain == nil {
+				return metadata.MarkAsGone(id)
+			}
+
+			state.Name = id.CustomDomainName
+			state.ContainerAppId = containerAppId.ID()
+
+			if v := domain.CertificateId; v != nil {
+				certificateId, err := managedenvironments.ParseCertificateIDInsensitively(*v)
+				if err != nil {
+					return err
+				}
+				state.ContainerAppEnvironmentCertificateId = certificateId.ID()
+
+				if domain.BindingType != nil {
+					state.CertificateBindingType = string(*domain.BindingType)
+				}
+			}
+
+			return metadata.Encode(&state)
+		},
+	}
+}
+
+func (r ContainerAppCustomDomainResource) Delete() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 30 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.ContainerApps.ContainerAppClient
+
+			id, err := parse.ContainerAppCustomDomainID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			containerAppId := containerapps.NewContainerAppID(id.SubscriptionId, id.ResourceGroup, id.ContainerAppName)
+
+			locks.ByID(containerAppId.ID())
+			defer locks.UnlockByID(containerAppId.ID())
+
+			model, err := containerAppForCustomDomainUpdate(ctx, client, containerAppId)
+			if err != nil {
+				return err
+			}
+
+			ingress := model.Properties.Configuration.Ingress
+			if ingress == nil || ingress.CustomDomains == nil {
+				return nil
+			}
+
+			customDomains := make([]containerapps.CustomDomain, 0)
+			for _, v := range *ingress.CustomDomains {
+				if !strings.EqualFold(v.Name, id.CustomDomainName) {
+					customDomains = append(customDomains, v)
+				}
+			}
+			ingress.CustomDomains = &customDomains
+
+			if err := client.CreateOrUpdateThenPoll(ctx, containerAppId, *model); err != nil {
+				return fmt.Errorf("deleting %s: %+v", *id, err)
+			}
+
+			return nil
+		},
+	}
+}
+
+// containerAppForCustomDomainUpdate retrieves the Container App including its secrets, so that it can be sent back
+// to the API without removing them
+func containerAppForCustomDomainUpdate(ctx context.Context, client *containerapps.ContainerAppsClient, id containerapps.ContainerAppId) (*containerapps.ContainerApp, error) {
+	existing, err := client.Get(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("reading %s: %+v", id, err)
+	}
+
+	model := existing.Model
+	if model == nil || model.Properties == nil {
+		return nil, fmt.Errorf("retrieving %s: `properties` was nil", id)
+	}
+
+	if model.Properties.Configuration == nil {
+		model.Properties.Configuration = &containerapps.Configuration{}
+	}
+
+	secretsResp, err := client.ListSecrets(ctx, id)
+	if err != nil || secretsResp.Model == nil {
+		if secretsResp.HttpResponse == nil || secretsResp.HttpResponse.StatusCode != http.StatusNoContent {
+			return nil, fmt.Errorf("retrieving secrets for update for %s: %+v", id, err)
+		}
+	}
+	model.Properties.Configuration.Secrets = helpers.UnpackContainerSecretsCollection(secretsResp.Model)
+
+	return model, nil
+}
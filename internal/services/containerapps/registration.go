@@ -27,6 +27,7 @@ func (r Registration) DataSources() []sdk.DataSource {
 
 func (r Registration) Resources() []sdk.Resource {
 	return []sdk.Resource{
+		ContainerAppCustomDomainResource{},
 		ContainerAppEnvironmentCertificateResource{},
 		ContainerAppEnvironmentDaprComponentResource{},
 		ContainerAppEnvironmentResource{},
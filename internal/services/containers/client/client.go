@@ -1,13 +1,23 @@
 package client
 
 import (
-	"github.com/Azure/azure-sdk-for-go/services/preview/containerregistry/mgmt/2021-08-01-preview/containerregistry" // nolint: staticcheck
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/services/preview/containerregistry/mgmt/2021-08-01-preview/containerregistry"                             // nolint: staticcheck
+	containerregistryruntime "github.com/Azure/azure-sdk-for-go/services/preview/containerregistry/runtime/2019-08-15-preview/containerregistry" // nolint: staticcheck
+	"github.com/Azure/go-autorest/autorest"
 	"github.com/Azure/go-autorest/autorest/azure"
 	"github.com/hashicorp/go-azure-sdk/resource-manager/containerinstance/2021-10-01/containerinstance"
 	"github.com/hashicorp/go-azure-sdk/resource-manager/containerservice/2019-08-01/containerservices"
 	"github.com/hashicorp/go-azure-sdk/resource-manager/containerservice/2022-09-02-preview/agentpools"
 	"github.com/hashicorp/go-azure-sdk/resource-manager/containerservice/2022-09-02-preview/maintenanceconfigurations"
 	"github.com/hashicorp/go-azure-sdk/resource-manager/containerservice/2022-09-02-preview/managedclusters"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/containerservice/2022-09-02-preview/snapshots"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/kubernetesconfiguration/2022-11-01/extensions"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/kubernetesconfiguration/2022-11-01/fluxconfiguration"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/common"
 )
 
@@ -16,10 +26,13 @@ type Client struct {
 	ContainerRegistryAgentPoolsClient *containerregistry.AgentPoolsClient
 	ContainerInstanceClient           *containerinstance.ContainerInstanceClient
 	KubernetesClustersClient          *managedclusters.ManagedClustersClient
+	KubernetesExtensionsClient        *extensions.ExtensionsClient
+	KubernetesFluxConfigurationClient *fluxconfiguration.FluxConfigurationClient
 	MaintenanceConfigurationsClient   *maintenanceconfigurations.MaintenanceConfigurationsClient
 	RegistriesClient                  *containerregistry.RegistriesClient
 	ReplicationsClient                *containerregistry.ReplicationsClient
 	ServicesClient                    *containerservices.ContainerServicesClient
+	SnapshotsClient                   *snapshots.SnapshotsClient
 	WebhooksClient                    *containerregistry.WebhooksClient
 	TokensClient                      *containerregistry.TokensClient
 	ScopeMapsClient                   *containerregistry.ScopeMapsClient
@@ -28,6 +41,53 @@ type Client struct {
 	ConnectedRegistriesClient         *containerregistry.ConnectedRegistriesClient
 
 	Environment azure.Environment
+
+	tenantId                  string
+	resourceManagerAuthorizer autorest.Authorizer
+	configureClientFunc       func(c *autorest.Client, authorizer autorest.Authorizer)
+}
+
+// RegistryTagsDataPlaneClient returns a client for the Tags of the specified repository within the Container Registry
+// available at loginServer. The Container Registry data plane doesn't accept Azure Active Directory tokens directly,
+// so the token used for Resource Manager is exchanged for one which is scoped to reading the repository's metadata.
+func (c Client) RegistryTagsDataPlaneClient(ctx context.Context, loginServer string, repository string) (*containerregistryruntime.TagClient, error) {
+	loginUri := fmt.Sprintf("https://%s", loginServer)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, loginUri, nil)
+	if err != nil {
+		return nil, fmt.Errorf("building request to obtain an auth token for %q: %+v", loginServer, err)
+	}
+	req, err = autorest.Prepare(req, c.resourceManagerAuthorizer.WithAuthorization())
+	if err != nil {
+		return nil, fmt.Errorf("obtaining auth token for %q: %+v", loginServer, err)
+	}
+	aadToken := strings.TrimPrefix(req.Header.Get("Authorization"), "Bearer ")
+
+	refreshTokensClient := containerregistryruntime.NewRefreshTokensClient(loginUri)
+	c.configureClientFunc(&refreshTokensClient.Client, autorest.NullAuthorizer{})
+	refreshToken, err := refreshTokensClient.GetFromExchange(ctx, "access_token", loginServer, c.tenantId, "", aadToken)
+	if err != nil {
+		return nil, fmt.Errorf("exchanging auth token for %q: %+v", loginServer, err)
+	}
+	if refreshToken.RefreshToken == nil {
+		return nil, fmt.Errorf("exchanging auth token for %q: `refresh_token` was nil", loginServer)
+	}
+
+	accessTokensClient := containerregistryruntime.NewAccessTokensClient(loginUri)
+	c.configureClientFunc(&accessTokensClient.Client, autorest.NullAuthorizer{})
+	accessToken, err := accessTokensClient.Get(ctx, loginServer, fmt.Sprintf("repository:%s:metadata_read", repository), *refreshToken.RefreshToken)
+	if err != nil {
+		return nil, fmt.Errorf("obtaining access token for repository %q within %q: %+v", repository, loginServer, err)
+	}
+	if accessToken.AccessToken == nil {
+		return nil, fmt.Errorf("obtaining access token for repository %q within %q: `access_token` was nil", repository, loginServer)
+	}
+
+	client := containerregistryruntime.NewTagClient(loginUri)
+	c.configureClientFunc(&client.Client, autorest.NewAPIKeyAuthorizerWithHeaders(map[string]interface{}{
+		"Authorization": fmt.Sprintf("Bearer %s", *accessToken.AccessToken),
+	}))
+	return &client, nil
 }
 
 func NewContainersClient(o *common.ClientOptions) *Client {
@@ -68,6 +128,15 @@ func NewContainersClient(o *common.ClientOptions) *Client {
 	maintenanceConfigurationsClient := maintenanceconfigurations.NewMaintenanceConfigurationsClientWithBaseURI(o.ResourceManagerEndpoint)
 	o.ConfigureClient(&maintenanceConfigurationsClient.Client, o.ResourceManagerAuthorizer)
 
+	kubernetesExtensionsClient := extensions.NewExtensionsClientWithBaseURI(o.ResourceManagerEndpoint)
+	o.ConfigureClient(&kubernetesExtensionsClient.Client, o.ResourceManagerAuthorizer)
+
+	kubernetesFluxConfigurationClient := fluxconfiguration.NewFluxConfigurationClientWithBaseURI(o.ResourceManagerEndpoint)
+	o.ConfigureClient(&kubernetesFluxConfigurationClient.Client, o.ResourceManagerAuthorizer)
+
+	snapshotsClient := snapshots.NewSnapshotsClientWithBaseURI(o.ResourceManagerEndpoint)
+	o.ConfigureClient(&snapshotsClient.Client, o.ResourceManagerAuthorizer)
+
 	servicesClient := containerservices.NewContainerServicesClientWithBaseURI(o.ResourceManagerEndpoint)
 	o.ConfigureClient(&servicesClient.Client, o.ResourceManagerAuthorizer)
 
@@ -78,17 +147,24 @@ func NewContainersClient(o *common.ClientOptions) *Client {
 		AgentPoolsClient:                  &agentPoolsClient,
 		ContainerRegistryAgentPoolsClient: &registryAgentPoolsClient,
 		KubernetesClustersClient:          &kubernetesClustersClient,
+		KubernetesExtensionsClient:        &kubernetesExtensionsClient,
+		KubernetesFluxConfigurationClient: &kubernetesFluxConfigurationClient,
 		ContainerInstanceClient:           &containerInstanceClient,
 		MaintenanceConfigurationsClient:   &maintenanceConfigurationsClient,
 		RegistriesClient:                  &registriesClient,
 		WebhooksClient:                    &webhooksClient,
 		ReplicationsClient:                &replicationsClient,
 		ServicesClient:                    &servicesClient,
+		SnapshotsClient:                   &snapshotsClient,
 		Environment:                       o.Environment,
 		TokensClient:                      &tokensClient,
 		ScopeMapsClient:                   &scopeMapsClient,
 		TasksClient:                       &tasksClient,
 		RunsClient:                        &runsClient,
 		ConnectedRegistriesClient:         &connectedRegistriesClient,
+
+		tenantId:                  o.TenantID,
+		resourceManagerAuthorizer: o.ResourceManagerAuthorizer,
+		configureClientFunc:       o.ConfigureClient,
 	}
 }
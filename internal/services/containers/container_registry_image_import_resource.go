@@ -0,0 +1,319 @@
+package containers
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/services/preview/containerregistry/mgmt/2021-08-01-preview/containerregistry" // nolint: staticcheck
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/containers/parse"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/containers/validate"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/validation"
+	"github.com/hashicorp/terraform-provider-azurerm/utils"
+)
+
+type ContainerRegistryImageImportResource struct{}
+
+var _ sdk.Resource = ContainerRegistryImageImportResource{}
+
+type ContainerRegistryImageImportModel struct {
+	Name                      string                                    `tfschema:"name"`
+	ContainerRegistryId       string                                    `tfschema:"container_registry_id"`
+	SourceImage               string                                    `tfschema:"source_image"`
+	SourceContainerRegistryId string                                    `tfschema:"source_container_registry_id"`
+	SourceRegistryUri         string                                    `tfschema:"source_registry_uri"`
+	SourceCredentials         []ContainerRegistryImageImportCredentials `tfschema:"source_credentials"`
+	TargetTags                []string                                  `tfschema:"target_tags"`
+	ForceEnabled              bool                                      `tfschema:"force_enabled"`
+}
+
+type ContainerRegistryImageImportCredentials struct {
+	Username string `tfschema:"username"`
+	Password string `tfschema:"password"`
+}
+
+func (r ContainerRegistryImageImportResource) Arguments() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"name": {
+			Type:     pluginsdk.TypeString,
+			Required: true,
+			ForceNew: true,
+			ValidateFunc: validation.StringMatch(
+				regexp.MustCompile(`^[a-zA-Z0-9][-_a-zA-Z0-9]{0,49}$`),
+				"`name` must be between 1 and 50 characters, can contain only letters, numbers, underscores and hyphens and must begin with a letter or number",
+			),
+		},
+
+		"container_registry_id": {
+			Type:         pluginsdk.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: validate.RegistryID,
+		},
+
+		"source_image": {
+			Type:         pluginsdk.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: validation.StringIsNotEmpty,
+		},
+
+		"source_container_registry_id": {
+			Type:         pluginsdk.TypeString,
+			Optional:     true,
+			ForceNew:     true,
+			ValidateFunc: validate.RegistryID,
+			ExactlyOneOf: []string{"source_container_registry_id", "source_registry_uri"},
+		},
+
+		"source_registry_uri": {
+			Type:         pluginsdk.TypeString,
+			Optional:     true,
+			ForceNew:     true,
+			ValidateFunc: validation.StringIsNotEmpty,
+			ExactlyOneOf: []string{"source_container_registry_id", "source_registry_uri"},
+		},
+
+		"source_credentials": {
+			Type:     pluginsdk.TypeList,
+			Optional: true,
+			ForceNew: true,
+			MaxItems: 1,
+			Elem: &pluginsdk.Resource{
+				Schema: map[string]*pluginsdk.Schema{
+					"username": {
+						Type:         pluginsdk.TypeString,
+						Optional:     true,
+						ForceNew:     true,
+						ValidateFunc: validation.StringIsNotEmpty,
+					},
+
+					"password": {
+						Type:         pluginsdk.TypeString,
+						Required:     true,
+						ForceNew:     true,
+						Sensitive:    true,
+						ValidateFunc: validation.StringIsNotEmpty,
+					},
+				},
+			},
+		},
+
+		"target_tags": {
+			Type:     pluginsdk.TypeList,
+			Required: true,
+			ForceNew: true,
+			MinItems: 1,
+			Elem: &pluginsdk.Schema{
+				Type:         pluginsdk.TypeString,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+		},
+
+		"force_enabled": {
+			Type:     pluginsdk.TypeBool,
+			Optional: true,
+			ForceNew: true,
+			Default:  false,
+		},
+	}
+}
+
+func (r ContainerRegistryImageImportResource) Attributes() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{}
+}
+
+func (r ContainerRegistryImageImportResource) ResourceType() string {
+	return "azurerm_container_registry_image_import"
+}
+
+func (r ContainerRegistryImageImportResource) ModelObject() interface{} {
+	return &ContainerRegistryImageImportModel{}
+}
+
+func (r ContainerRegistryImageImportResource) IDValidationFunc() pluginsdk.SchemaValidateFunc {
+	return validate.ContainerRegistryImageImportID
+}
+
+func (r ContainerRegistryImageImportResource) Create() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 30 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.Containers.RegistriesClient
+
+			var model ContainerRegistryImageImportModel
+			if err := metadata.Decode(&model); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			registryId, err := parse.RegistryID(model.ContainerRegistryId)
+			if err != nil {
+				return err
+			}
+
+			id := parse.NewContainerRegistryImageImportID(registryId.SubscriptionId, registryId.ResourceGroup, registryId.Name, model.Name)
+
+			// the import itself isn't tracked by the API, so the existence of the target tags is checked instead - unless
+			// the import is forced, in which case any existing tags are intentionally overwritten
+			if !model.ForceEnabled {
+				registry, err := client.Get(ctx, registryId.ResourceGroup, registryId.Name)
+				if err != nil {
+					return fmt.Errorf("retrieving %s: %+v", *registryId, err)
+				}
+
+				existingTags, err := containerRegistryImageImportExistingTags(ctx, metadata, registry, model.TargetTags)
+				if err != nil {
+					return fmt.Errorf("checking for presence of existing %s: %+v", id, err)
+				}
+				if len(existingTags) > 0 {
+					return metadata.ResourceRequiresImport(r.ResourceType(), id)
+				}
+			}
+
+			source := containerregistry.ImportSource{
+				SourceImage: utils.String(model.SourceImage),
+			}
+			if model.SourceContainerRegistryId != "" {
+				sourceRegistryId, err := parse.RegistryID(model.SourceContainerRegistryId)
+				if err != nil {
+					return err
+				}
+				source.ResourceID = utils.String(sourceRegistryId.ID())
+			}
+			if model.SourceRegistryUri != "" {
+				source.RegistryURI = utils.String(model.SourceRegistryUri)
+			}
+			if len(model.SourceCredentials) > 0 {
+				credentials := model.SourceCredentials[0]
+				source.Credentials = &containerregistry.ImportSourceCredentials{
+					Password: utils.String(credentials.Password),
+				}
+				if credentials.Username != "" {
+					source.Credentials.Username = utils.String(credentials.Username)
+				}
+			}
+
+			mode := containerregistry.ImportModeNoForce
+			if model.ForceEnabled {
+				mode = containerregistry.ImportModeForce
+			}
+
+			parameters := containerregistry.ImportImageParameters{
+				Source:     &source,
+				TargetTags: &model.TargetTags,
+				Mode:       mode,
+			}
+
+			future, err := client.ImportImage(ctx, registryId.ResourceGroup, registryId.Name, parameters)
+			if err != nil {
+				return fmt.Errorf("importing %q into %s: %+v", model.SourceImage, *registryId, err)
+			}
+			if err := future.WaitForCompletionRef(ctx, client.Client); err != nil {
+				return fmt.Errorf("waiting for the import of %q into %s: %+v", model.SourceImage, *registryId, err)
+			}
+
+			metadata.SetID(id)
+			return nil
+		},
+	}
+}
+
+func (r ContainerRegistryImageImportResource) Read() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 5 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.Containers.RegistriesClient
+
+			id, err := parse.ContainerRegistryImageImportID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			var state ContainerRegistryImageImportModel
+			if err := metadata.Decode(&state); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			// the import is a one-off operation which isn't tracked by the API, as such only the existence of the
+			// Container Registry and the target tags is checked here and the remaining arguments are kept as defined
+			// in the state
+			registryId := parse.NewRegistryID(id.SubscriptionId, id.ResourceGroup, id.RegistryName)
+			resp, err := client.Get(ctx, registryId.ResourceGroup, registryId.Name)
+			if err != nil {
+				if utils.ResponseWasNotFound(resp.Response) {
+					return metadata.MarkAsGone(id)
+				}
+
+				return fmt.Errorf("retrieving %s: %+v", registryId, err)
+			}
+
+			existingTags, err := containerRegistryImageImportExistingTags(ctx, metadata, resp, state.TargetTags)
+			if err != nil {
+				return fmt.Errorf("retrieving the target tags of %s: %+v", *id, err)
+			}
+			if len(existingTags) != len(state.TargetTags) {
+				log.Printf("[DEBUG] %d of the %d target tags of %s no longer exist - removing from state", len(state.TargetTags)-len(existingTags), len(state.TargetTags), *id)
+				return metadata.MarkAsGone(id)
+			}
+
+			state.Name = id.ImportName
+			state.ContainerRegistryId = registryId.ID()
+
+			return metadata.Encode(&state)
+		},
+	}
+}
+
+func (r ContainerRegistryImageImportResource) Delete() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 30 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			// the imported images are left in the Container Registry, so this is only removed from the state
+			return nil
+		},
+	}
+}
+
+// containerRegistryImageImportExistingTags returns the target tags which exist within the Container Registry, where
+// each target tag is in the format `repository[:tag]` and the tag defaults to `latest` when omitted
+func containerRegistryImageImportExistingTags(ctx context.Context, metadata sdk.ResourceMetaData, registry containerregistry.Registry, targetTags []string) ([]string, error) {
+	if len(targetTags) == 0 {
+		return nil, nil
+	}
+
+	if registry.RegistryProperties == nil || registry.RegistryProperties.LoginServer == nil {
+		return nil, fmt.Errorf("`loginServer` was nil")
+	}
+	loginServer := *registry.RegistryProperties.LoginServer
+
+	existing := make([]string, 0)
+	for _, targetTag := range targetTags {
+		repository, tag := targetTag, "latest"
+		if i := strings.LastIndex(targetTag, ":"); i > strings.LastIndex(targetTag, "/") {
+			repository, tag = targetTag[:i], targetTag[i+1:]
+		}
+
+		client, err := metadata.Client.Containers.RegistryTagsDataPlaneClient(ctx, loginServer, repository)
+		if err != nil {
+			return nil, err
+		}
+
+		resp, err := client.GetAttributes(ctx, repository, tag)
+		if err != nil {
+			if utils.ResponseWasNotFound(resp.Response) {
+				continue
+			}
+
+			return nil, fmt.Errorf("retrieving tag %q: %+v", targetTag, err)
+		}
+
+		existing = append(existing, targetTag)
+	}
+
+	return existing, nil
+}
@@ -0,0 +1,156 @@
+package containers_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance/check"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/containers/parse"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/utils"
+)
+
+type ContainerRegistryImageImportResource struct{}
+
+func TestAccContainerRegistryImageImport_registryUri(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_container_registry_image_import", "test")
+	r := ContainerRegistryImageImportResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.registryUri(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep("source_image", "source_registry_uri", "target_tags", "force_enabled"),
+	})
+}
+
+func TestAccContainerRegistryImageImport_containerRegistry(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_container_registry_image_import", "test")
+	r := ContainerRegistryImageImportResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.containerRegistry(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep("source_image", "source_container_registry_id", "target_tags", "force_enabled"),
+	})
+}
+
+func TestAccContainerRegistryImageImport_requiresImport(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_container_registry_image_import", "test")
+	r := ContainerRegistryImageImportResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.registryUri(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.RequiresImportErrorStep(r.requiresImport),
+	})
+}
+
+func (r ContainerRegistryImageImportResource) Exists(ctx context.Context, clients *clients.Client, state *pluginsdk.InstanceState) (*bool, error) {
+	id, err := parse.ContainerRegistryImageImportID(state.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := clients.Containers.RegistriesClient.Get(ctx, id.ResourceGroup, id.RegistryName)
+	if err != nil {
+		if utils.ResponseWasNotFound(resp.Response) {
+			return utils.Bool(false), nil
+		}
+		return nil, fmt.Errorf("retrieving Container Registry %q (Resource Group %q): %+v", id.RegistryName, id.ResourceGroup, err)
+	}
+
+	return utils.Bool(true), nil
+}
+
+func (r ContainerRegistryImageImportResource) registryUri(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_container_registry_image_import" "test" {
+  name                  = "acctestimport%d"
+  container_registry_id = azurerm_container_registry.test.id
+  source_registry_uri   = "mcr.microsoft.com"
+  source_image          = "hello-world:latest"
+  target_tags           = ["hello-world:latest", "hello-world:v1"]
+}
+`, r.template(data), data.RandomInteger)
+}
+
+func (r ContainerRegistryImageImportResource) requiresImport(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_container_registry_image_import" "import" {
+  name                  = azurerm_container_registry_image_import.test.name
+  container_registry_id = azurerm_container_registry_image_import.test.container_registry_id
+  source_registry_uri   = azurerm_container_registry_image_import.test.source_registry_uri
+  source_image          = azurerm_container_registry_image_import.test.source_image
+  target_tags           = azurerm_container_registry_image_import.test.target_tags
+}
+`, r.registryUri(data))
+}
+
+func (r ContainerRegistryImageImportResource) containerRegistry(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%[1]s
+
+resource "azurerm_container_registry_image_import" "source" {
+  name                  = "acctestimport%[2]d"
+  container_registry_id = azurerm_container_registry.test.id
+  source_registry_uri   = "mcr.microsoft.com"
+  source_image          = "hello-world:latest"
+  target_tags           = ["hello-world:latest"]
+}
+
+resource "azurerm_container_registry" "target" {
+  name                = "acctestacrtarget%[2]d"
+  resource_group_name = azurerm_resource_group.test.name
+  location            = azurerm_resource_group.test.location
+  sku                 = "Basic"
+}
+
+resource "azurerm_container_registry_image_import" "test" {
+  name                         = "acctestimport%[2]d"
+  container_registry_id        = azurerm_container_registry.target.id
+  source_container_registry_id = azurerm_container_registry_image_import.source.container_registry_id
+  source_image                 = "hello-world:latest"
+  target_tags                  = ["hello-world:latest"]
+  force_enabled                = true
+}
+`, r.template(data), data.RandomInteger)
+}
+
+func (r ContainerRegistryImageImportResource) template(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+provider "azurerm" {
+  features {}
+}
+
+resource "azurerm_resource_group" "test" {
+  name     = "acctestRG-acr-%[1]d"
+  location = "%[2]s"
+}
+
+resource "azurerm_container_registry" "test" {
+  name                = "acctestacr%[1]d"
+  resource_group_name = azurerm_resource_group.test.name
+  location            = azurerm_resource_group.test.location
+  sku                 = "Basic"
+}
+`, data.RandomInteger, data.Locations.Primary)
+}
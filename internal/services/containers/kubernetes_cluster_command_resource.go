@@ -0,0 +1,295 @@
+package containers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-azure-helpers/lang/response"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/containerservice/2022-09-02-preview/managedclusters"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/validation"
+	"github.com/hashicorp/terraform-provider-azurerm/utils"
+)
+
+type KubernetesClusterCommandModel struct {
+	KubernetesClusterId string            `tfschema:"kubernetes_cluster_id"`
+	Command             string            `tfschema:"command"`
+	Context             string            `tfschema:"context"`
+	Triggers            map[string]string `tfschema:"triggers"`
+	ExitCode            int64             `tfschema:"exit_code"`
+	FinishedAt          string            `tfschema:"finished_at"`
+	Logs                string            `tfschema:"logs"`
+	StartedAt           string            `tfschema:"started_at"`
+}
+
+type KubernetesClusterCommandResource struct{}
+
+var _ sdk.Resource = KubernetesClusterCommandResource{}
+
+func (r KubernetesClusterCommandResource) ResourceType() string {
+	return "azurerm_kubernetes_cluster_command"
+}
+
+func (r KubernetesClusterCommandResource) ModelObject() interface{} {
+	return &KubernetesClusterCommandModel{}
+}
+
+func (r KubernetesClusterCommandResource) IDValidationFunc() pluginsdk.SchemaValidateFunc {
+	return managedclusters.ValidateCommandResultID
+}
+
+func (r KubernetesClusterCommandResource) Arguments() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"kubernetes_cluster_id": {
+			Type:         pluginsdk.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: managedclusters.ValidateManagedClusterID,
+		},
+
+		"command": {
+			Type:         pluginsdk.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: validation.StringIsNotEmpty,
+		},
+
+		"context": {
+			Type:         pluginsdk.TypeString,
+			Optional:     true,
+			ForceNew:     true,
+			ValidateFunc: validation.StringIsBase64,
+		},
+
+		"triggers": {
+			Type:     pluginsdk.TypeMap,
+			Optional: true,
+			ForceNew: true,
+			Elem: &pluginsdk.Schema{
+				Type: pluginsdk.TypeString,
+			},
+		},
+	}
+}
+
+func (r KubernetesClusterCommandResource) Attributes() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"exit_code": {
+			Type:     pluginsdk.TypeInt,
+			Computed: true,
+		},
+
+		"finished_at": {
+			Type:     pluginsdk.TypeString,
+			Computed: true,
+		},
+
+		"logs": {
+			Type:     pluginsdk.TypeString,
+			Computed: true,
+		},
+
+		"started_at": {
+			Type:     pluginsdk.TypeString,
+			Computed: true,
+		},
+	}
+}
+
+func (r KubernetesClusterCommandResource) Create() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 30 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			var model KubernetesClusterCommandModel
+			if err := metadata.Decode(&model); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			client := metadata.Client.Containers.KubernetesClustersClient
+			clusterId, err := managedclusters.ParseManagedClusterID(model.KubernetesClusterId)
+			if err != nil {
+				return err
+			}
+
+			input := managedclusters.RunCommandRequest{
+				Command: model.Command,
+			}
+			if model.Context != "" {
+				input.Context = utils.String(model.Context)
+			}
+
+			resp, err := client.RunCommand(ctx, *clusterId, input)
+			if err != nil {
+				return fmt.Errorf("running command on %s: %+v", *clusterId, err)
+			}
+
+			// a command which finishes quickly is returned synchronously, otherwise the ID of the Command Result is only
+			// returned within the `Location` header, which is then polled until the command has finished running
+			id, result, err := kubernetesClusterCommandResultFromResponse(*clusterId, resp)
+			if err != nil {
+				return fmt.Errorf("running command on %s: %+v", *clusterId, err)
+			}
+
+			if result == nil {
+				result, err = waitForKubernetesClusterCommandResult(ctx, client, *id)
+				if err != nil {
+					return err
+				}
+			}
+
+			if strings.EqualFold(utils.NormalizeNilableString(result.ProvisioningState), "Failed") {
+				return fmt.Errorf("running %s: the command could not be run: %s", *id, utils.NormalizeNilableString(result.Reason))
+			}
+
+			model.KubernetesClusterId = clusterId.ID()
+			model.ExitCode = 0
+			if result.ExitCode != nil {
+				model.ExitCode = *result.ExitCode
+			}
+			model.FinishedAt = utils.NormalizeNilableString(result.FinishedAt)
+			model.Logs = utils.NormalizeNilableString(result.Logs)
+			model.StartedAt = utils.NormalizeNilableString(result.StartedAt)
+
+			metadata.SetID(id)
+			return metadata.Encode(&model)
+		},
+	}
+}
+
+func (r KubernetesClusterCommandResource) Read() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 5 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.Containers.KubernetesClustersClient
+
+			id, err := managedclusters.ParseCommandResultID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			// the results of a command are only retained by the API for a short period of time, as such the outputs
+			// of the command are kept in the state and only the existence of the Kubernetes Cluster is checked here
+			clusterId := managedclusters.NewManagedClusterID(id.SubscriptionId, id.ResourceGroupName, id.ManagedClusterName)
+			resp, err := client.Get(ctx, clusterId)
+			if err != nil {
+				if response.WasNotFound(resp.HttpResponse) {
+					return metadata.MarkAsGone(id)
+				}
+
+				return fmt.Errorf("retrieving %s: %+v", clusterId, err)
+			}
+
+			return metadata.ResourceData.Set("kubernetes_cluster_id", clusterId.ID())
+		},
+	}
+}
+
+func (r KubernetesClusterCommandResource) Delete() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 5 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			// a command can't be undone, so this is only removed from the state
+			return nil
+		},
+	}
+}
+
+// kubernetesClusterCommandResultFromResponse returns the ID of the Command Result from the response to running a
+// command - and, when the command finished synchronously, the result of that command.
+func kubernetesClusterCommandResultFromResponse(clusterId managedclusters.ManagedClusterId, resp managedclusters.RunCommandOperationResponse) (*managedclusters.CommandResultId, *managedclusters.CommandResultProperties, error) {
+	if resp.HttpResponse == nil {
+		return nil, nil, fmt.Errorf("the response was nil")
+	}
+
+	switch resp.HttpResponse.StatusCode {
+	case http.StatusOK:
+		var result managedclusters.RunCommandResult
+		if err := json.NewDecoder(resp.HttpResponse.Body).Decode(&result); err != nil {
+			return nil, nil, fmt.Errorf("decoding the response: %+v", err)
+		}
+		if result.Id == nil || *result.Id == "" {
+			return nil, nil, fmt.Errorf("the response contained no `id`")
+		}
+
+		// the `id` is the ID of the command rather than a Resource ID
+		id := managedclusters.NewCommandResultID(clusterId.SubscriptionId, clusterId.ResourceGroupName, clusterId.ManagedClusterName, *result.Id)
+		if parsed, err := managedclusters.ParseCommandResultIDInsensitively(*result.Id); err == nil {
+			id = *parsed
+		}
+
+		// the command can still be running, in which case the Command Result is polled
+		if props := result.Properties; props != nil && props.ProvisioningState != nil {
+			if state := *props.ProvisioningState; strings.EqualFold(state, "Succeeded") || strings.EqualFold(state, "Failed") {
+				return &id, props, nil
+			}
+		}
+
+		return &id, nil, nil
+
+	case http.StatusAccepted:
+		location := resp.HttpResponse.Header.Get("Location")
+		if location == "" {
+			return nil, nil, fmt.Errorf("the `Location` header was empty")
+		}
+
+		locationUrl, err := url.Parse(location)
+		if err != nil {
+			return nil, nil, fmt.Errorf("parsing the `Location` header %q: %+v", location, err)
+		}
+
+		id, err := managedclusters.ParseCommandResultIDInsensitively(strings.TrimSuffix(locationUrl.Path, "/"))
+		if err != nil {
+			return nil, nil, err
+		}
+
+		return id, nil, nil
+	}
+
+	return nil, nil, fmt.Errorf("unexpected status code %d", resp.HttpResponse.StatusCode)
+}
+
+func waitForKubernetesClusterCommandResult(ctx context.Context, client *managedclusters.ManagedClustersClient, id managedclusters.CommandResultId) (*managedclusters.CommandResultProperties, error) {
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		return nil, fmt.Errorf("internal-error: context had no deadline")
+	}
+
+	stateConf := &pluginsdk.StateChangeConf{
+		Pending: []string{"Running"},
+		Target:  []string{"Succeeded", "Failed"},
+		Refresh: func() (interface{}, string, error) {
+			resp, err := client.GetCommandResult(ctx, id)
+			if err != nil {
+				return nil, "", fmt.Errorf("retrieving %s: %+v", id, err)
+			}
+
+			if resp.Model == nil || resp.Model.Properties == nil || resp.Model.Properties.ProvisioningState == nil {
+				return resp, "Running", nil
+			}
+
+			switch state := *resp.Model.Properties.ProvisioningState; {
+			case strings.EqualFold(state, "Succeeded"):
+				return resp, "Succeeded", nil
+			case strings.EqualFold(state, "Failed"):
+				return resp, "Failed", nil
+			}
+
+			return resp, "Running", nil
+		},
+		MinTimeout: 5 * time.Second,
+		Timeout:    time.Until(deadline),
+	}
+
+	raw, err := stateConf.WaitForStateContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("waiting for %s to finish running: %+v", id, err)
+	}
+
+	return raw.(managedclusters.GetCommandResultOperationResponse).Model.Properties, nil
+}
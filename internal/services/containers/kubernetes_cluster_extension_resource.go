@@ -0,0 +1,504 @@
+package containers
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"time"
+
+	"github.com/hashicorp/go-azure-helpers/lang/response"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/containerservice/2022-09-02-preview/managedclusters"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/kubernetesconfiguration/2022-11-01/extensions"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/validation"
+	"github.com/hashicorp/terraform-provider-azurerm/utils"
+)
+
+type KubernetesClusterExtensionModel struct {
+	Name                           string                                    `tfschema:"name"`
+	ClusterID                      string                                    `tfschema:"cluster_id"`
+	AksAssignedIdentity            []KubernetesClusterExtensionIdentityModel `tfschema:"aks_assigned_identity"`
+	ConfigurationProtectedSettings map[string]string                         `tfschema:"configuration_protected_settings"`
+	ConfigurationSettings          map[string]string                         `tfschema:"configuration_settings"`
+	CurrentVersion                 string                                    `tfschema:"current_version"`
+	ExtensionType                  string                                    `tfschema:"extension_type"`
+	Plan                           []KubernetesClusterExtensionPlanModel     `tfschema:"plan"`
+	ReleaseTrain                   string                                    `tfschema:"release_train"`
+	ReleaseNamespace               string                                    `tfschema:"release_namespace"`
+	TargetNamespace                string                                    `tfschema:"target_namespace"`
+	Version                        string                                    `tfschema:"version"`
+}
+
+type KubernetesClusterExtensionIdentityModel struct {
+	PrincipalId string `tfschema:"principal_id"`
+	TenantId    string `tfschema:"tenant_id"`
+	Type        string `tfschema:"type"`
+}
+
+type KubernetesClusterExtensionPlanModel struct {
+	Name          string `tfschema:"name"`
+	Product       string `tfschema:"product"`
+	PromotionCode string `tfschema:"promotion_code"`
+	Publisher     string `tfschema:"publisher"`
+	Version       string `tfschema:"version"`
+}
+
+type KubernetesClusterExtensionResource struct{}
+
+var _ sdk.ResourceWithUpdate = KubernetesClusterExtensionResource{}
+
+func (r KubernetesClusterExtensionResource) ResourceType() string {
+	return "azurerm_kubernetes_cluster_extension"
+}
+
+func (r KubernetesClusterExtensionResource) ModelObject() interface{} {
+	return &KubernetesClusterExtensionModel{}
+}
+
+func (r KubernetesClusterExtensionResource) IDValidationFunc() pluginsdk.SchemaValidateFunc {
+	return extensions.ValidateExtensionID
+}
+
+func (r KubernetesClusterExtensionResource) Arguments() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"name": {
+			Type:     pluginsdk.TypeString,
+			Required: true,
+			ForceNew: true,
+			ValidateFunc: validation.StringMatch(
+				regexp.MustCompile(`^[a-z0-9]([-.a-z0-9]{0,251}[a-z0-9])?$`),
+				"`name` must be between 1 and 253 characters, contain only lowercase alphanumeric characters, hyphens and periods, and must start and end with an alphanumeric character",
+			),
+		},
+
+		"cluster_id": {
+			Type:         pluginsdk.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: managedclusters.ValidateManagedClusterID,
+		},
+
+		"extension_type": {
+			Type:         pluginsdk.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: validation.StringIsNotEmpty,
+		},
+
+		"configuration_protected_settings": {
+			Type:      pluginsdk.TypeMap,
+			Optional:  true,
+			Sensitive: true,
+			Elem: &pluginsdk.Schema{
+				Type:         pluginsdk.TypeString,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+		},
+
+		"configuration_settings": {
+			Type:     pluginsdk.TypeMap,
+			Optional: true,
+			Elem: &pluginsdk.Schema{
+				Type:         pluginsdk.TypeString,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+		},
+
+		"plan": {
+			Type:     pluginsdk.TypeList,
+			Optional: true,
+			ForceNew: true,
+			MaxItems: 1,
+			Elem: &pluginsdk.Resource{
+				Schema: map[string]*pluginsdk.Schema{
+					"name": {
+						Type:         pluginsdk.TypeString,
+						Required:     true,
+						ForceNew:     true,
+						ValidateFunc: validation.StringIsNotEmpty,
+					},
+
+					"product": {
+						Type:         pluginsdk.TypeString,
+						Required:     true,
+						ForceNew:     true,
+						ValidateFunc: validation.StringIsNotEmpty,
+					},
+
+					"publisher": {
+						Type:         pluginsdk.TypeString,
+						Required:     true,
+						ForceNew:     true,
+						ValidateFunc: validation.StringIsNotEmpty,
+					},
+
+					"promotion_code": {
+						Type:         pluginsdk.TypeString,
+						Optional:     true,
+						ForceNew:     true,
+						ValidateFunc: validation.StringIsNotEmpty,
+					},
+
+					"version": {
+						Type:         pluginsdk.TypeString,
+						Optional:     true,
+						ForceNew:     true,
+						ValidateFunc: validation.StringIsNotEmpty,
+					},
+				},
+			},
+		},
+
+		"release_train": {
+			Type:          pluginsdk.TypeString,
+			Optional:      true,
+			Computed:      true,
+			ValidateFunc:  validation.StringIsNotEmpty,
+			ConflictsWith: []string{"version"},
+		},
+
+		"release_namespace": {
+			Type:          pluginsdk.TypeString,
+			Optional:      true,
+			ForceNew:      true,
+			Computed:      true,
+			ValidateFunc:  validation.StringIsNotEmpty,
+			ConflictsWith: []string{"target_namespace"},
+		},
+
+		"target_namespace": {
+			Type:          pluginsdk.TypeString,
+			Optional:      true,
+			ForceNew:      true,
+			ValidateFunc:  validation.StringIsNotEmpty,
+			ConflictsWith: []string{"release_namespace"},
+		},
+
+		"version": {
+			Type:          pluginsdk.TypeString,
+			Optional:      true,
+			Computed:      true,
+			ValidateFunc:  validation.StringIsNotEmpty,
+			ConflictsWith: []string{"release_train"},
+		},
+	}
+}
+
+func (r KubernetesClusterExtensionResource) Attributes() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"aks_assigned_identity": {
+			Type:     pluginsdk.TypeList,
+			Computed: true,
+			Elem: &pluginsdk.Resource{
+				Schema: map[string]*pluginsdk.Schema{
+					"principal_id": {
+						Type:     pluginsdk.TypeString,
+						Computed: true,
+					},
+
+					"tenant_id": {
+						Type:     pluginsdk.TypeString,
+						Computed: true,
+					},
+
+					"type": {
+						Type:     pluginsdk.TypeString,
+						Computed: true,
+					},
+				},
+			},
+		},
+
+		"current_version": {
+			Type:     pluginsdk.TypeString,
+			Computed: true,
+		},
+	}
+}
+
+func (r KubernetesClusterExtensionResource) Create() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 30 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			var model KubernetesClusterExtensionModel
+			if err := metadata.Decode(&model); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			client := metadata.Client.Containers.KubernetesExtensionsClient
+			clusterId, err := managedclusters.ParseManagedClusterID(model.ClusterID)
+			if err != nil {
+				return err
+			}
+
+			id := extensions.NewExtensionID(clusterId.SubscriptionId, clusterId.ResourceGroupName, "Microsoft.ContainerService", "managedClusters", clusterId.ManagedClusterName, model.Name)
+
+			existing, err := client.Get(ctx, id)
+			if err != nil && !response.WasNotFound(existing.HttpResponse) {
+				return fmt.Errorf("checking for existing %s: %+v", id, err)
+			}
+
+			if !response.WasNotFound(existing.HttpResponse) {
+				return metadata.ResourceRequiresImport(r.ResourceType(), id)
+			}
+
+			properties := extensions.Extension{
+				Properties: &extensions.ExtensionProperties{
+					AutoUpgradeMinorVersion:        utils.Bool(true),
+					ConfigurationProtectedSettings: &model.ConfigurationProtectedSettings,
+					ConfigurationSettings:          &model.ConfigurationSettings,
+					ExtensionType:                  &model.ExtensionType,
+					Scope:                          expandKubernetesClusterExtensionScope(model),
+				},
+				Plan: expandKubernetesClusterExtensionPlan(model.Plan),
+			}
+
+			if model.ReleaseTrain != "" {
+				properties.Properties.ReleaseTrain = &model.ReleaseTrain
+			}
+
+			// when a version is specified the Extension must be pinned to it, rather than upgraded automatically
+			if model.Version != "" {
+				properties.Properties.AutoUpgradeMinorVersion = utils.Bool(false)
+				properties.Properties.Version = &model.Version
+			}
+
+			if err := client.CreateThenPoll(ctx, id, properties); err != nil {
+				return fmt.Errorf("creating %s: %+v", id, err)
+			}
+
+			metadata.SetID(id)
+			return nil
+		},
+	}
+}
+
+func (r KubernetesClusterExtensionResource) Update() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 30 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.Containers.KubernetesExtensionsClient
+
+			id, err := extensions.ParseExtensionID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			var model KubernetesClusterExtensionModel
+			if err := metadata.Decode(&model); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			properties := extensions.PatchExtension{
+				Properties: &extensions.PatchExtensionProperties{},
+			}
+
+			if metadata.ResourceData.HasChange("configuration_protected_settings") {
+				properties.Properties.ConfigurationProtectedSettings = &model.ConfigurationProtectedSettings
+			}
+
+			if metadata.ResourceData.HasChange("configuration_settings") {
+				properties.Properties.ConfigurationSettings = &model.ConfigurationSettings
+			}
+
+			if metadata.ResourceData.HasChange("release_train") {
+				properties.Properties.ReleaseTrain = &model.ReleaseTrain
+			}
+
+			if metadata.ResourceData.HasChange("version") {
+				properties.Properties.AutoUpgradeMinorVersion = utils.Bool(model.Version == "")
+				if model.Version != "" {
+					properties.Properties.Version = &model.Version
+				}
+			}
+
+			if err := client.UpdateThenPoll(ctx, *id, properties); err != nil {
+				return fmt.Errorf("updating %s: %+v", *id, err)
+			}
+
+			return nil
+		},
+	}
+}
+
+func (r KubernetesClusterExtensionResource) Read() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 5 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.Containers.KubernetesExtensionsClient
+
+			id, err := extensions.ParseExtensionID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			resp, err := client.Get(ctx, *id)
+			if err != nil {
+				if response.WasNotFound(resp.HttpResponse) {
+					return metadata.MarkAsGone(id)
+				}
+
+				return fmt.Errorf("retrieving %s: %+v", *id, err)
+			}
+
+			var state KubernetesClusterExtensionModel
+			if err := metadata.Decode(&state); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			model := KubernetesClusterExtensionModel{
+				Name:      id.ExtensionName,
+				ClusterID: managedclusters.NewManagedClusterID(id.SubscriptionId, id.ResourceGroupName, id.ClusterName).ID(),
+				// the API doesn't return the protected settings, so these are pulled from the state
+				ConfigurationProtectedSettings: state.ConfigurationProtectedSettings,
+			}
+
+			if respModel := resp.Model; respModel != nil {
+				model.Plan = flattenKubernetesClusterExtensionPlan(respModel.Plan)
+
+				if props := respModel.Properties; props != nil {
+					model.AksAssignedIdentity = flattenKubernetesClusterExtensionIdentity(props.AksAssignedIdentity)
+
+					if props.ConfigurationSettings != nil {
+						model.ConfigurationSettings = *props.ConfigurationSettings
+					}
+
+					if props.CurrentVersion != nil {
+						model.CurrentVersion = *props.CurrentVersion
+					}
+
+					if props.ExtensionType != nil {
+						model.ExtensionType = *props.ExtensionType
+					}
+
+					if props.ReleaseTrain != nil {
+						model.ReleaseTrain = *props.ReleaseTrain
+					}
+
+					if props.Version != nil {
+						model.Version = *props.Version
+					}
+
+					if scope := props.Scope; scope != nil {
+						if scope.Cluster != nil && scope.Cluster.ReleaseNamespace != nil {
+							model.ReleaseNamespace = *scope.Cluster.ReleaseNamespace
+						}
+
+						if scope.Namespace != nil && scope.Namespace.TargetNamespace != nil {
+							model.TargetNamespace = *scope.Namespace.TargetNamespace
+						}
+					}
+				}
+			}
+
+			return metadata.Encode(&model)
+		},
+	}
+}
+
+func (r KubernetesClusterExtensionResource) Delete() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 30 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.Containers.KubernetesExtensionsClient
+
+			id, err := extensions.ParseExtensionID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			if err := client.DeleteThenPoll(ctx, *id, extensions.DeleteOperationOptions{}); err != nil {
+				return fmt.Errorf("deleting %s: %+v", *id, err)
+			}
+
+			return nil
+		},
+	}
+}
+
+func expandKubernetesClusterExtensionScope(model KubernetesClusterExtensionModel) *extensions.Scope {
+	if model.TargetNamespace != "" {
+		return &extensions.Scope{
+			Namespace: &extensions.ScopeNamespace{
+				TargetNamespace: &model.TargetNamespace,
+			},
+		}
+	}
+
+	if model.ReleaseNamespace != "" {
+		return &extensions.Scope{
+			Cluster: &extensions.ScopeCluster{
+				ReleaseNamespace: &model.ReleaseNamespace,
+			},
+		}
+	}
+
+	return nil
+}
+
+func expandKubernetesClusterExtensionPlan(input []KubernetesClusterExtensionPlanModel) *extensions.Plan {
+	if len(input) == 0 {
+		return nil
+	}
+
+	plan := input[0]
+	output := extensions.Plan{
+		Name:      plan.Name,
+		Product:   plan.Product,
+		Publisher: plan.Publisher,
+	}
+
+	if plan.PromotionCode != "" {
+		output.PromotionCode = &plan.PromotionCode
+	}
+
+	if plan.Version != "" {
+		output.Version = &plan.Version
+	}
+
+	return &output
+}
+
+func flattenKubernetesClusterExtensionPlan(input *extensions.Plan) []KubernetesClusterExtensionPlanModel {
+	if input == nil {
+		return make([]KubernetesClusterExtensionPlanModel, 0)
+	}
+
+	output := KubernetesClusterExtensionPlanModel{
+		Name:      input.Name,
+		Product:   input.Product,
+		Publisher: input.Publisher,
+	}
+
+	if input.PromotionCode != nil {
+		output.PromotionCode = *input.PromotionCode
+	}
+
+	if input.Version != nil {
+		output.Version = *input.Version
+	}
+
+	return []KubernetesClusterExtensionPlanModel{output}
+}
+
+func flattenKubernetesClusterExtensionIdentity(input *extensions.ExtensionPropertiesAksAssignedIdentity) []KubernetesClusterExtensionIdentityModel {
+	if input == nil {
+		return make([]KubernetesClusterExtensionIdentityModel, 0)
+	}
+
+	output := KubernetesClusterExtensionIdentityModel{}
+	if input.PrincipalId != nil {
+		output.PrincipalId = *input.PrincipalId
+	}
+
+	if input.TenantId != nil {
+		output.TenantId = *input.TenantId
+	}
+
+	if input.Type != nil {
+		output.Type = string(*input.Type)
+	}
+
+	return []KubernetesClusterExtensionIdentityModel{output}
+}
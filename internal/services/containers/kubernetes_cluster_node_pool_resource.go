@@ -1,6 +1,7 @@
 package containers
 
 import (
+	"context"
 	"encoding/base64"
 	"fmt"
 	"log"
@@ -15,6 +16,7 @@ import (
 	"github.com/hashicorp/go-azure-sdk/resource-manager/compute/2022-03-01/proximityplacementgroups"
 	"github.com/hashicorp/go-azure-sdk/resource-manager/containerservice/2022-09-02-preview/agentpools"
 	"github.com/hashicorp/go-azure-sdk/resource-manager/containerservice/2022-09-02-preview/managedclusters"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/containerservice/2022-09-02-preview/snapshots"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-provider-azurerm/helpers/azure"
 	"github.com/hashicorp/terraform-provider-azurerm/helpers/tf"
@@ -54,6 +56,13 @@ func resourceKubernetesClusterNodePool() *pluginsdk.Resource {
 			0: migration.KubernetesClusterNodePoolV0ToV1{},
 		}),
 
+		CustomizeDiff: pluginsdk.CustomDiffInSequence(
+			// the API doesn't support removing the Snapshot a Node Pool was created from
+			pluginsdk.ForceNewIfChange("snapshot_id", func(ctx context.Context, old, new, meta interface{}) bool {
+				return old != "" && new == ""
+			}),
+		),
+
 		Schema: map[string]*pluginsdk.Schema{
 			"name": {
 				Type:         pluginsdk.TypeString,
@@ -312,6 +321,12 @@ func resourceKubernetesClusterNodePool() *pluginsdk.Resource {
 				}, false),
 			},
 
+			"snapshot_id": {
+				Type:         pluginsdk.TypeString,
+				Optional:     true,
+				ValidateFunc: snapshots.ValidateSnapshotID,
+			},
+
 			"ultra_ssd_enabled": {
 				Type:     pluginsdk.TypeBool,
 				ForceNew: true,
@@ -534,6 +549,12 @@ func resourceKubernetesClusterNodePoolCreate(d *pluginsdk.ResourceData, meta int
 		profile.CapacityReservationGroupID = utils.String(capacityReservationGroupId)
 	}
 
+	if snapshotId := d.Get("snapshot_id").(string); snapshotId != "" {
+		profile.CreationData = &agentpools.CreationData{
+			SourceResourceId: utils.String(snapshotId),
+		}
+	}
+
 	maxCount := d.Get("max_count").(int)
 	minCount := d.Get("min_count").(int)
 
@@ -674,6 +695,25 @@ func resourceKubernetesClusterNodePoolUpdate(d *pluginsdk.ResourceData, meta int
 		props.NodePublicIPPrefixID = utils.String(d.Get("node_public_ip_prefix_id").(string))
 	}
 
+	// removing the `snapshot_id` forces a new resource, so it can only be changed to a different Snapshot here
+	if d.HasChange("snapshot_id") {
+		if snapshotId := d.Get("snapshot_id").(string); snapshotId != "" {
+			props.CreationData = &agentpools.CreationData{
+				SourceResourceId: utils.String(snapshotId),
+			}
+
+			// moving to a different Snapshot upgrades the Node Pool to the Kubernetes Version the Snapshot was taken
+			// from, unless a different version has been explicitly specified
+			if !d.HasChange("orchestrator_version") {
+				snapshotVersion, err := kubernetesVersionForNodePoolSnapshot(ctx, containersClient, snapshotId)
+				if err != nil {
+					return err
+				}
+				props.OrchestratorVersion = utils.String(snapshotVersion)
+			}
+		}
+	}
+
 	if d.HasChange("orchestrator_version") {
 		existingNodePoolResp, err := client.Get(ctx, *id)
 		if err != nil {
@@ -936,6 +976,16 @@ func resourceKubernetesClusterNodePoolRead(d *pluginsdk.ResourceData, meta inter
 		d.Set("host_group_id", props.HostGroupID)
 		d.Set("capacity_reservation_group_id", props.CapacityReservationGroupID)
 
+		snapshotId := ""
+		if props.CreationData != nil && props.CreationData.SourceResourceId != nil {
+			parsedSnapshotId, err := snapshots.ParseSnapshotIDInsensitively(*props.CreationData.SourceResourceId)
+			if err != nil {
+				return err
+			}
+			snapshotId = parsedSnapshotId.ID()
+		}
+		d.Set("snapshot_id", snapshotId)
+
 		if err := d.Set("upgrade_settings", flattenAgentPoolUpgradeSettings(props.UpgradeSettings)); err != nil {
 			return fmt.Errorf("setting `upgrade_settings`: %+v", err)
 		}
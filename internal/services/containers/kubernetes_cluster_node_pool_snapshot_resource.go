@@ -0,0 +1,290 @@
+package containers
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"time"
+
+	"github.com/hashicorp/go-azure-helpers/lang/response"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonschema"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/location"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/containerservice/2022-09-02-preview/agentpools"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/containerservice/2022-09-02-preview/snapshots"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/containers/client"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/validation"
+	"github.com/hashicorp/terraform-provider-azurerm/utils"
+)
+
+type KubernetesClusterNodePoolSnapshotModel struct {
+	Name              string            `tfschema:"name"`
+	ResourceGroupName string            `tfschema:"resource_group_name"`
+	Location          string            `tfschema:"location"`
+	SourceNodePoolId  string            `tfschema:"source_node_pool_id"`
+	FipsEnabled       bool              `tfschema:"fips_enabled"`
+	KubernetesVersion string            `tfschema:"kubernetes_version"`
+	NodeImageVersion  string            `tfschema:"node_image_version"`
+	OsSku             string            `tfschema:"os_sku"`
+	OsType            string            `tfschema:"os_type"`
+	VmSize            string            `tfschema:"vm_size"`
+	Tags              map[string]string `tfschema:"tags"`
+}
+
+type KubernetesClusterNodePoolSnapshotResource struct{}
+
+var _ sdk.ResourceWithUpdate = KubernetesClusterNodePoolSnapshotResource{}
+
+func (r KubernetesClusterNodePoolSnapshotResource) ResourceType() string {
+	return "azurerm_kubernetes_cluster_node_pool_snapshot"
+}
+
+func (r KubernetesClusterNodePoolSnapshotResource) ModelObject() interface{} {
+	return &KubernetesClusterNodePoolSnapshotModel{}
+}
+
+func (r KubernetesClusterNodePoolSnapshotResource) IDValidationFunc() pluginsdk.SchemaValidateFunc {
+	return snapshots.ValidateSnapshotID
+}
+
+func (r KubernetesClusterNodePoolSnapshotResource) Arguments() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"name": {
+			Type:     pluginsdk.TypeString,
+			Required: true,
+			ForceNew: true,
+			ValidateFunc: validation.StringMatch(
+				regexp.MustCompile(`^[a-zA-Z0-9]$|^[a-zA-Z0-9][-_a-zA-Z0-9]{0,61}[a-zA-Z0-9]$`),
+				"`name` must be between 1 and 63 characters, can contain only letters, numbers, underscores and hyphens and must begin and end with a letter or number",
+			),
+		},
+
+		"resource_group_name": commonschema.ResourceGroupName(),
+
+		"location": commonschema.Location(),
+
+		"source_node_pool_id": {
+			Type:         pluginsdk.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: agentpools.ValidateAgentPoolID,
+		},
+
+		"tags": commonschema.Tags(),
+	}
+}
+
+func (r KubernetesClusterNodePoolSnapshotResource) Attributes() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"fips_enabled": {
+			Type:     pluginsdk.TypeBool,
+			Computed: true,
+		},
+
+		"kubernetes_version": {
+			Type:     pluginsdk.TypeString,
+			Computed: true,
+		},
+
+		"node_image_version": {
+			Type:     pluginsdk.TypeString,
+			Computed: true,
+		},
+
+		"os_sku": {
+			Type:     pluginsdk.TypeString,
+			Computed: true,
+		},
+
+		"os_type": {
+			Type:     pluginsdk.TypeString,
+			Computed: true,
+		},
+
+		"vm_size": {
+			Type:     pluginsdk.TypeString,
+			Computed: true,
+		},
+	}
+}
+
+func (r KubernetesClusterNodePoolSnapshotResource) Create() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 30 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			var model KubernetesClusterNodePoolSnapshotModel
+			if err := metadata.Decode(&model); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			client := metadata.Client.Containers.SnapshotsClient
+			subscriptionId := metadata.Client.Account.SubscriptionId
+			id := snapshots.NewSnapshotID(subscriptionId, model.ResourceGroupName, model.Name)
+
+			existing, err := client.Get(ctx, id)
+			if err != nil && !response.WasNotFound(existing.HttpResponse) {
+				return fmt.Errorf("checking for existing %s: %+v", id, err)
+			}
+
+			if !response.WasNotFound(existing.HttpResponse) {
+				return metadata.ResourceRequiresImport(r.ResourceType(), id)
+			}
+
+			nodePoolId, err := agentpools.ParseAgentPoolID(model.SourceNodePoolId)
+			if err != nil {
+				return err
+			}
+
+			properties := snapshots.Snapshot{
+				Location: location.Normalize(model.Location),
+				Properties: &snapshots.SnapshotProperties{
+					CreationData: &snapshots.CreationData{
+						SourceResourceId: utils.String(nodePoolId.ID()),
+					},
+					SnapshotType: utils.ToPtr(snapshots.SnapshotTypeNodePool),
+				},
+				Tags: &model.Tags,
+			}
+
+			if _, err := client.CreateOrUpdate(ctx, id, properties); err != nil {
+				return fmt.Errorf("creating %s: %+v", id, err)
+			}
+
+			metadata.SetID(id)
+			return nil
+		},
+	}
+}
+
+func (r KubernetesClusterNodePoolSnapshotResource) Update() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 30 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.Containers.SnapshotsClient
+
+			id, err := snapshots.ParseSnapshotID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			var model KubernetesClusterNodePoolSnapshotModel
+			if err := metadata.Decode(&model); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			if metadata.ResourceData.HasChange("tags") {
+				parameters := snapshots.TagsObject{
+					Tags: &model.Tags,
+				}
+				if _, err := client.UpdateTags(ctx, *id, parameters); err != nil {
+					return fmt.Errorf("updating %s: %+v", *id, err)
+				}
+			}
+
+			return nil
+		},
+	}
+}
+
+func (r KubernetesClusterNodePoolSnapshotResource) Read() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 5 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.Containers.SnapshotsClient
+
+			id, err := snapshots.ParseSnapshotID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			resp, err := client.Get(ctx, *id)
+			if err != nil {
+				if response.WasNotFound(resp.HttpResponse) {
+					return metadata.MarkAsGone(id)
+				}
+
+				return fmt.Errorf("retrieving %s: %+v", *id, err)
+			}
+
+			state := KubernetesClusterNodePoolSnapshotModel{
+				Name:              id.SnapshotName,
+				ResourceGroupName: id.ResourceGroupName,
+			}
+
+			if model := resp.Model; model != nil {
+				state.Location = location.Normalize(model.Location)
+
+				if props := model.Properties; props != nil {
+					if props.CreationData != nil && props.CreationData.SourceResourceId != nil {
+						nodePoolId, err := agentpools.ParseAgentPoolIDInsensitively(*props.CreationData.SourceResourceId)
+						if err != nil {
+							return err
+						}
+						state.SourceNodePoolId = nodePoolId.ID()
+					}
+
+					state.FipsEnabled = utils.NormaliseNilableBool(props.EnableFIPS)
+					state.KubernetesVersion = utils.NormalizeNilableString(props.KubernetesVersion)
+					state.NodeImageVersion = utils.NormalizeNilableString(props.NodeImageVersion)
+					state.VmSize = utils.NormalizeNilableString(props.VMSize)
+
+					if v := props.OsSku; v != nil {
+						state.OsSku = string(*v)
+					}
+
+					if v := props.OsType; v != nil {
+						state.OsType = string(*v)
+					}
+				}
+
+				if model.Tags != nil {
+					state.Tags = *model.Tags
+				}
+			}
+
+			return metadata.Encode(&state)
+		},
+	}
+}
+
+func (r KubernetesClusterNodePoolSnapshotResource) Delete() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 30 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.Containers.SnapshotsClient
+
+			id, err := snapshots.ParseSnapshotID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			if resp, err := client.Delete(ctx, *id); err != nil {
+				if !response.WasNotFound(resp.HttpResponse) {
+					return fmt.Errorf("deleting %s: %+v", *id, err)
+				}
+			}
+
+			return nil
+		},
+	}
+}
+
+// kubernetesVersionForNodePoolSnapshot returns the Kubernetes Version of the Node Pool the specified Snapshot was taken from
+func kubernetesVersionForNodePoolSnapshot(ctx context.Context, client *client.Client, snapshotId string) (string, error) {
+	id, err := snapshots.ParseSnapshotIDInsensitively(snapshotId)
+	if err != nil {
+		return "", err
+	}
+
+	resp, err := client.SnapshotsClient.Get(ctx, *id)
+	if err != nil {
+		return "", fmt.Errorf("retrieving %s: %+v", *id, err)
+	}
+
+	if model := resp.Model; model != nil && model.Properties != nil && model.Properties.KubernetesVersion != nil {
+		return *model.Properties.KubernetesVersion, nil
+	}
+
+	return "", fmt.Errorf("retrieving %s: `properties.kubernetesVersion` was nil", *id)
+}
@@ -72,6 +72,23 @@ func resourceKubernetesCluster() *pluginsdk.Resource {
 			pluginsdk.ForceNewIfChange("api_server_access_profile.0.subnet_id", func(ctx context.Context, old, new, meta interface{}) bool {
 				return old != "" && new == ""
 			}),
+			// these properties can only be changed by rotating the Default Node Pool, when a temporary name hasn't been
+			// specified for that the Kubernetes Cluster has to be recreated instead
+			func(ctx context.Context, diff *pluginsdk.ResourceDiff, v interface{}) error {
+				if diff.Id() == "" || diff.Get("default_node_pool.0.temporary_name_for_rotation").(string) != "" {
+					return nil
+				}
+
+				for _, key := range defaultNodePoolRotationProperties {
+					if diff.HasChange(key) {
+						if err := diff.ForceNew(key); err != nil {
+							return err
+						}
+					}
+				}
+
+				return nil
+			},
 		),
 
 		Timeouts: &pluginsdk.ResourceTimeout{
@@ -1988,6 +2005,18 @@ func resourceKubernetesClusterUpdate(d *pluginsdk.ResourceData, meta interface{}
 		agentProfile := ConvertDefaultNodePoolToAgentPool(agentProfiles)
 		defaultNodePoolId := agentpools.NewAgentPoolID(id.SubscriptionId, id.ResourceGroupName, id.ManagedClusterName, *agentProfile.Name)
 
+		// moving to a different Snapshot upgrades the Default Node Pool to the Kubernetes Version the Snapshot was
+		// taken from, unless a different version has been explicitly specified
+		if d.HasChange("default_node_pool.0.snapshot_id") && !d.HasChange("default_node_pool.0.orchestrator_version") {
+			if snapshotId := d.Get("default_node_pool.0.snapshot_id").(string); snapshotId != "" {
+				snapshotVersion, err := kubernetesVersionForNodePoolSnapshot(ctx, containersClient, snapshotId)
+				if err != nil {
+					return err
+				}
+				agentProfile.Properties.OrchestratorVersion = utils.String(snapshotVersion)
+			}
+		}
+
 		// if a users specified a version - confirm that version is supported on the cluster
 		if nodePoolVersion := agentProfile.Properties.CurrentOrchestratorVersion; nodePoolVersion != nil {
 			existingNodePool, err := nodePoolsClient.Get(ctx, defaultNodePoolId)
@@ -2004,13 +2033,32 @@ func resourceKubernetesClusterUpdate(d *pluginsdk.ResourceData, meta interface{}
 			}
 		}
 
-		agentPool, err := nodePoolsClient.CreateOrUpdate(ctx, defaultNodePoolId, agentProfile)
-		if err != nil {
-			return fmt.Errorf("updating Default Node Pool %s %+v", defaultNodePoolId, err)
+		rotateNodePool := false
+		for _, key := range defaultNodePoolRotationProperties {
+			if d.HasChange(key) {
+				rotateNodePool = true
+				break
+			}
 		}
 
-		if err := agentPool.Poller.PollUntilDone(); err != nil {
-			return fmt.Errorf("waiting for update of Default Node Pool %s: %+v", defaultNodePoolId, err)
+		if rotateNodePool {
+			temporaryNodePoolName := d.Get("default_node_pool.0.temporary_name_for_rotation").(string)
+			if temporaryNodePoolName == "" {
+				return fmt.Errorf("`temporary_name_for_rotation` must be specified when updating any of the following properties %q", defaultNodePoolRotationProperties)
+			}
+
+			if err := rotateKubernetesClusterDefaultNodePool(ctx, nodePoolsClient, defaultNodePoolId, temporaryNodePoolName, agentProfile); err != nil {
+				return err
+			}
+		} else {
+			agentPool, err := nodePoolsClient.CreateOrUpdate(ctx, defaultNodePoolId, agentProfile)
+			if err != nil {
+				return fmt.Errorf("updating Default Node Pool %s %+v", defaultNodePoolId, err)
+			}
+
+			if err := agentPool.Poller.PollUntilDone(); err != nil {
+				return fmt.Errorf("waiting for update of Default Node Pool %s: %+v", defaultNodePoolId, err)
+			}
 		}
 		log.Printf("[DEBUG] Updated Default Node Pool.")
 	}
@@ -3792,3 +3840,69 @@ func flattenKubernetesClusterAzureMonitorProfile(input *managedclusters.ManagedC
 		},
 	}
 }
+
+// defaultNodePoolRotationProperties are the properties of the Default Node Pool which can't be updated in-place,
+// changing any of these requires the Default Node Pool to be rotated using `temporary_name_for_rotation`
+var defaultNodePoolRotationProperties = []string{
+	"default_node_pool.0.enable_host_encryption",
+	"default_node_pool.0.enable_node_public_ip",
+	"default_node_pool.0.fips_enabled",
+	"default_node_pool.0.max_pods",
+	"default_node_pool.0.only_critical_addons_enabled",
+	"default_node_pool.0.os_disk_size_gb",
+	"default_node_pool.0.os_disk_type",
+	"default_node_pool.0.os_sku",
+	"default_node_pool.0.pod_subnet_id",
+	"default_node_pool.0.snapshot_id",
+	"default_node_pool.0.ultra_ssd_enabled",
+	"default_node_pool.0.vm_size",
+	"default_node_pool.0.vnet_subnet_id",
+	"default_node_pool.0.zones",
+}
+
+// rotateKubernetesClusterDefaultNodePool replaces the Default Node Pool without recreating the Kubernetes Cluster.
+// A temporary System Node Pool using the new configuration is created so that workloads can be moved onto it
+// whilst the Default Node Pool is deleted and recreated, after which the temporary Node Pool is removed.
+func rotateKubernetesClusterDefaultNodePool(ctx context.Context, client *agentpools.AgentPoolsClient, defaultNodePoolId agentpools.AgentPoolId, temporaryNodePoolName string, agentProfile agentpools.AgentPool) error {
+	temporaryNodePoolId := agentpools.NewAgentPoolID(defaultNodePoolId.SubscriptionId, defaultNodePoolId.ResourceGroupName, defaultNodePoolId.ManagedClusterName, temporaryNodePoolName)
+
+	existing, err := client.Get(ctx, defaultNodePoolId)
+	if err != nil && !response.WasNotFound(existing.HttpResponse) {
+		return fmt.Errorf("retrieving Default Node Pool %s: %+v", defaultNodePoolId, err)
+	}
+
+	temporaryExisting, err := client.Get(ctx, temporaryNodePoolId)
+	if err != nil && !response.WasNotFound(temporaryExisting.HttpResponse) {
+		return fmt.Errorf("checking for existing temporary Node Pool %s: %+v", temporaryNodePoolId, err)
+	}
+
+	// the temporary Node Pool may already exist should a previous rotation have failed part-way through
+	if temporaryExisting.Model == nil {
+		log.Printf("[DEBUG] Creating temporary %s..", temporaryNodePoolId)
+		temporaryProfile := agentProfile
+		temporaryProfile.Name = utils.String(temporaryNodePoolName)
+		if err := client.CreateOrUpdateThenPoll(ctx, temporaryNodePoolId, temporaryProfile); err != nil {
+			return fmt.Errorf("creating temporary Node Pool %s: %+v", temporaryNodePoolId, err)
+		}
+	}
+
+	if existing.Model != nil {
+		log.Printf("[DEBUG] Deleting Default Node Pool %s..", defaultNodePoolId)
+		if err := client.DeleteThenPoll(ctx, defaultNodePoolId, agentpools.DefaultDeleteOperationOptions()); err != nil {
+			return fmt.Errorf("deleting Default Node Pool %s: %+v", defaultNodePoolId, err)
+		}
+	}
+
+	log.Printf("[DEBUG] Recreating Default Node Pool %s..", defaultNodePoolId)
+	if err := client.CreateOrUpdateThenPoll(ctx, defaultNodePoolId, agentProfile); err != nil {
+		// the temporary Node Pool is retained so that the Kubernetes Cluster keeps a System Node Pool
+		return fmt.Errorf("recreating Default Node Pool %s, the temporary Node Pool %q has been retained: %+v", defaultNodePoolId, temporaryNodePoolName, err)
+	}
+
+	log.Printf("[DEBUG] Deleting temporary %s..", temporaryNodePoolId)
+	if err := client.DeleteThenPoll(ctx, temporaryNodePoolId, agentpools.DefaultDeleteOperationOptions()); err != nil {
+		return fmt.Errorf("deleting temporary Node Pool %s: %+v", temporaryNodePoolId, err)
+	}
+
+	return nil
+}
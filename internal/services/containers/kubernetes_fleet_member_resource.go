@@ -0,0 +1,177 @@
+package containers
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"time"
+
+	"github.com/hashicorp/go-azure-helpers/lang/response"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/containerservice/2022-09-02-preview/fleetmembers"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/containerservice/2022-09-02-preview/managedclusters"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/validation"
+	"github.com/hashicorp/terraform-provider-azurerm/utils"
+)
+
+type KubernetesFleetMemberModel struct {
+	Name                string `tfschema:"name"`
+	KubernetesFleetId   string `tfschema:"kubernetes_fleet_id"`
+	KubernetesClusterId string `tfschema:"kubernetes_cluster_id"`
+}
+
+type KubernetesFleetMemberResource struct{}
+
+var _ sdk.Resource = KubernetesFleetMemberResource{}
+
+func (r KubernetesFleetMemberResource) ResourceType() string {
+	return "azurerm_kubernetes_fleet_member"
+}
+
+func (r KubernetesFleetMemberResource) ModelObject() interface{} {
+	return &KubernetesFleetMemberModel{}
+}
+
+func (r KubernetesFleetMemberResource) IDValidationFunc() pluginsdk.SchemaValidateFunc {
+	return fleetmembers.ValidateMemberID
+}
+
+func (r KubernetesFleetMemberResource) Arguments() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"name": {
+			Type:     pluginsdk.TypeString,
+			Required: true,
+			ForceNew: true,
+			ValidateFunc: validation.StringMatch(
+				regexp.MustCompile(`^[a-z0-9]([-a-z0-9]{0,48}[a-z0-9])?$`),
+				"`name` must be between 1 and 50 characters, can contain only lowercase letters, numbers and hyphens and must begin and end with a lowercase letter or number",
+			),
+		},
+
+		"kubernetes_fleet_id": {
+			Type:         pluginsdk.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: fleetmembers.ValidateFleetID,
+		},
+
+		"kubernetes_cluster_id": {
+			Type:         pluginsdk.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: managedclusters.ValidateManagedClusterID,
+		},
+	}
+}
+
+func (r KubernetesFleetMemberResource) Attributes() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{}
+}
+
+func (r KubernetesFleetMemberResource) Create() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 30 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			var model KubernetesFleetMemberModel
+			if err := metadata.Decode(&model); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			client := metadata.Client.ContainerService.FleetMembers
+			fleetId, err := fleetmembers.ParseFleetID(model.KubernetesFleetId)
+			if err != nil {
+				return err
+			}
+
+			clusterId, err := managedclusters.ParseManagedClusterID(model.KubernetesClusterId)
+			if err != nil {
+				return err
+			}
+
+			id := fleetmembers.NewMemberID(fleetId.SubscriptionId, fleetId.ResourceGroupName, fleetId.FleetName, model.Name)
+
+			existing, err := client.Get(ctx, id)
+			if err != nil && !response.WasNotFound(existing.HttpResponse) {
+				return fmt.Errorf("checking for existing %s: %+v", id, err)
+			}
+
+			if !response.WasNotFound(existing.HttpResponse) {
+				return metadata.ResourceRequiresImport(r.ResourceType(), id)
+			}
+
+			properties := fleetmembers.FleetMember{
+				Properties: &fleetmembers.FleetMemberProperties{
+					ClusterResourceId: utils.String(clusterId.ID()),
+				},
+			}
+
+			if err := client.CreateOrUpdateThenPoll(ctx, id, properties, fleetmembers.DefaultCreateOrUpdateOperationOptions()); err != nil {
+				return fmt.Errorf("creating %s: %+v", id, err)
+			}
+
+			metadata.SetID(id)
+			return nil
+		},
+	}
+}
+
+func (r KubernetesFleetMemberResource) Read() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 5 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.ContainerService.FleetMembers
+
+			id, err := fleetmembers.ParseMemberID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			resp, err := client.Get(ctx, *id)
+			if err != nil {
+				if response.WasNotFound(resp.HttpResponse) {
+					return metadata.MarkAsGone(id)
+				}
+
+				return fmt.Errorf("retrieving %s: %+v", *id, err)
+			}
+
+			state := KubernetesFleetMemberModel{
+				Name:              id.MemberName,
+				KubernetesFleetId: fleetmembers.NewFleetID(id.SubscriptionId, id.ResourceGroupName, id.FleetName).ID(),
+			}
+
+			if model := resp.Model; model != nil {
+				if props := model.Properties; props != nil && props.ClusterResourceId != nil {
+					clusterId, err := managedclusters.ParseManagedClusterIDInsensitively(*props.ClusterResourceId)
+					if err != nil {
+						return err
+					}
+					state.KubernetesClusterId = clusterId.ID()
+				}
+			}
+
+			return metadata.Encode(&state)
+		},
+	}
+}
+
+func (r KubernetesFleetMemberResource) Delete() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 30 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.ContainerService.FleetMembers
+
+			id, err := fleetmembers.ParseMemberID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			if err := client.DeleteThenPoll(ctx, *id, fleetmembers.DefaultDeleteOperationOptions()); err != nil {
+				return fmt.Errorf("deleting %s: %+v", *id, err)
+			}
+
+			return nil
+		},
+	}
+}
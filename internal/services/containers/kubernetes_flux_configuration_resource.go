@@ -0,0 +1,979 @@
+package containers
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-azure-helpers/lang/response"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/containerservice/2022-09-02-preview/managedclusters"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/kubernetesconfiguration/2022-11-01/fluxconfiguration"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/validation"
+	"github.com/hashicorp/terraform-provider-azurerm/utils"
+)
+
+type KubernetesFluxConfigurationModel struct {
+	Name                            string                         `tfschema:"name"`
+	ClusterID                       string                         `tfschema:"cluster_id"`
+	Namespace                       string                         `tfschema:"namespace"`
+	Scope                           string                         `tfschema:"scope"`
+	GitRepository                   []GitRepositoryDefinitionModel `tfschema:"git_repository"`
+	Bucket                          []BucketDefinitionModel        `tfschema:"bucket"`
+	Kustomizations                  []KustomizationDefinitionModel `tfschema:"kustomizations"`
+	ConfigurationProtectedSettings  map[string]string              `tfschema:"configuration_protected_settings"`
+	ContinuousReconciliationEnabled bool                           `tfschema:"continuous_reconciliation_enabled"`
+	WaitForCompliance               bool                           `tfschema:"wait_for_compliance"`
+	ComplianceState                 string                         `tfschema:"compliance_state"`
+}
+
+type GitRepositoryDefinitionModel struct {
+	Url                   string `tfschema:"url"`
+	HttpsCACertBase64     string `tfschema:"https_ca_cert_base64"`
+	HttpsUser             string `tfschema:"https_user"`
+	HttpsKeyBase64        string `tfschema:"https_key_base64"`
+	LocalAuthReference    string `tfschema:"local_auth_reference"`
+	ReferenceType         string `tfschema:"reference_type"`
+	ReferenceValue        string `tfschema:"reference_value"`
+	SshKnownHostsBase64   string `tfschema:"ssh_known_hosts_base64"`
+	SshPrivateKeyBase64   string `tfschema:"ssh_private_key_base64"`
+	SyncIntervalInSeconds int64  `tfschema:"sync_interval_in_seconds"`
+	TimeoutInSeconds      int64  `tfschema:"timeout_in_seconds"`
+}
+
+type BucketDefinitionModel struct {
+	Url                   string `tfschema:"url"`
+	BucketName            string `tfschema:"bucket_name"`
+	AccessKey             string `tfschema:"access_key"`
+	SecretKeyBase64       string `tfschema:"secret_key_base64"`
+	TlsEnabled            bool   `tfschema:"tls_enabled"`
+	LocalAuthReference    string `tfschema:"local_auth_reference"`
+	SyncIntervalInSeconds int64  `tfschema:"sync_interval_in_seconds"`
+	TimeoutInSeconds      int64  `tfschema:"timeout_in_seconds"`
+}
+
+type KustomizationDefinitionModel struct {
+	Name                     string   `tfschema:"name"`
+	Path                     string   `tfschema:"path"`
+	DependsOn                []string `tfschema:"depends_on"`
+	GarbageCollectionEnabled bool     `tfschema:"garbage_collection_enabled"`
+	RecreatingEnabled        bool     `tfschema:"recreating_enabled"`
+	RetryIntervalInSeconds   int64    `tfschema:"retry_interval_in_seconds"`
+	SyncIntervalInSeconds    int64    `tfschema:"sync_interval_in_seconds"`
+	TimeoutInSeconds         int64    `tfschema:"timeout_in_seconds"`
+}
+
+const (
+	fluxReferenceTypeBranch = "branch"
+	fluxReferenceTypeCommit = "commit"
+	fluxReferenceTypeSemver = "semver"
+	fluxReferenceTypeTag    = "tag"
+
+	// these are the keys within `configurationProtectedSettings` used to store the credentials for the source
+	fluxProtectedSettingHttpsKey      = "httpsKey"
+	fluxProtectedSettingSshPrivateKey = "sshPrivateKey"
+	fluxProtectedSettingBucketSecret  = "bucketSecretKey"
+)
+
+type KubernetesFluxConfigurationResource struct{}
+
+var _ sdk.ResourceWithUpdate = KubernetesFluxConfigurationResource{}
+
+func (r KubernetesFluxConfigurationResource) ResourceType() string {
+	return "azurerm_kubernetes_flux_configuration"
+}
+
+func (r KubernetesFluxConfigurationResource) ModelObject() interface{} {
+	return &KubernetesFluxConfigurationModel{}
+}
+
+func (r KubernetesFluxConfigurationResource) IDValidationFunc() pluginsdk.SchemaValidateFunc {
+	return fluxconfiguration.ValidateFluxConfigurationID
+}
+
+func (r KubernetesFluxConfigurationResource) Arguments() map[string]*pluginsdk.Schema {
+	syncInterval := func() *pluginsdk.Schema {
+		return &pluginsdk.Schema{
+			Type:         pluginsdk.TypeInt,
+			Optional:     true,
+			Default:      600,
+			ValidateFunc: validation.IntAtLeast(1),
+		}
+	}
+
+	timeout := func() *pluginsdk.Schema {
+		return &pluginsdk.Schema{
+			Type:         pluginsdk.TypeInt,
+			Optional:     true,
+			Default:      600,
+			ValidateFunc: validation.IntAtLeast(1),
+		}
+	}
+
+	return map[string]*pluginsdk.Schema{
+		"name": {
+			Type:         pluginsdk.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: validateKubernetesFluxName,
+		},
+
+		"cluster_id": {
+			Type:         pluginsdk.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: managedclusters.ValidateManagedClusterID,
+		},
+
+		"namespace": {
+			Type:         pluginsdk.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: validateKubernetesFluxName,
+		},
+
+		"kustomizations": {
+			Type:     pluginsdk.TypeSet,
+			Required: true,
+			Elem: &pluginsdk.Resource{
+				Schema: map[string]*pluginsdk.Schema{
+					"name": {
+						Type:         pluginsdk.TypeString,
+						Required:     true,
+						ValidateFunc: validateKubernetesFluxName,
+					},
+
+					"path": {
+						Type:         pluginsdk.TypeString,
+						Optional:     true,
+						ValidateFunc: validation.StringIsNotEmpty,
+					},
+
+					"depends_on": {
+						Type:     pluginsdk.TypeList,
+						Optional: true,
+						Elem: &pluginsdk.Schema{
+							Type:         pluginsdk.TypeString,
+							ValidateFunc: validateKubernetesFluxName,
+						},
+					},
+
+					"garbage_collection_enabled": {
+						Type:     pluginsdk.TypeBool,
+						Optional: true,
+						Default:  false,
+					},
+
+					"recreating_enabled": {
+						Type:     pluginsdk.TypeBool,
+						Optional: true,
+						Default:  false,
+					},
+
+					"retry_interval_in_seconds": syncInterval(),
+
+					"sync_interval_in_seconds": syncInterval(),
+
+					"timeout_in_seconds": timeout(),
+				},
+			},
+		},
+
+		"git_repository": {
+			Type:         pluginsdk.TypeList,
+			Optional:     true,
+			MaxItems:     1,
+			ExactlyOneOf: []string{"git_repository", "bucket"},
+			Elem: &pluginsdk.Resource{
+				Schema: map[string]*pluginsdk.Schema{
+					"url": {
+						Type:     pluginsdk.TypeString,
+						Required: true,
+						ValidateFunc: validation.All(
+							validation.StringIsNotEmpty,
+							validation.StringMatch(regexp.MustCompile(`^(http|https|ssh)://`), "`url` must start with `http://`, `https://` or `ssh://`"),
+						),
+					},
+
+					"reference_type": {
+						Type:     pluginsdk.TypeString,
+						Required: true,
+						ValidateFunc: validation.StringInSlice([]string{
+							fluxReferenceTypeBranch,
+							fluxReferenceTypeCommit,
+							fluxReferenceTypeSemver,
+							fluxReferenceTypeTag,
+						}, false),
+					},
+
+					"reference_value": {
+						Type:         pluginsdk.TypeString,
+						Required:     true,
+						ValidateFunc: validation.StringIsNotEmpty,
+					},
+
+					"https_ca_cert_base64": {
+						Type:         pluginsdk.TypeString,
+						Optional:     true,
+						ValidateFunc: validation.StringIsBase64,
+					},
+
+					"https_user": {
+						Type:          pluginsdk.TypeString,
+						Optional:      true,
+						ValidateFunc:  validation.StringIsNotEmpty,
+						RequiredWith:  []string{"git_repository.0.https_key_base64"},
+						ConflictsWith: []string{"git_repository.0.local_auth_reference", "git_repository.0.ssh_private_key_base64", "git_repository.0.ssh_known_hosts_base64"},
+					},
+
+					"https_key_base64": {
+						Type:         pluginsdk.TypeString,
+						Optional:     true,
+						Sensitive:    true,
+						ValidateFunc: validation.StringIsBase64,
+						RequiredWith: []string{"git_repository.0.https_user"},
+					},
+
+					"local_auth_reference": {
+						Type:          pluginsdk.TypeString,
+						Optional:      true,
+						ValidateFunc:  validateKubernetesFluxName,
+						ConflictsWith: []string{"git_repository.0.https_user", "git_repository.0.ssh_private_key_base64", "git_repository.0.ssh_known_hosts_base64"},
+					},
+
+					"ssh_private_key_base64": {
+						Type:          pluginsdk.TypeString,
+						Optional:      true,
+						Sensitive:     true,
+						ValidateFunc:  validation.StringIsBase64,
+						ConflictsWith: []string{"git_repository.0.https_user", "git_repository.0.local_auth_reference"},
+					},
+
+					"ssh_known_hosts_base64": {
+						Type:          pluginsdk.TypeString,
+						Optional:      true,
+						ValidateFunc:  validation.StringIsBase64,
+						ConflictsWith: []string{"git_repository.0.https_user", "git_repository.0.local_auth_reference"},
+					},
+
+					"sync_interval_in_seconds": syncInterval(),
+
+					"timeout_in_seconds": timeout(),
+				},
+			},
+		},
+
+		"bucket": {
+			Type:         pluginsdk.TypeList,
+			Optional:     true,
+			MaxItems:     1,
+			ExactlyOneOf: []string{"git_repository", "bucket"},
+			Elem: &pluginsdk.Resource{
+				Schema: map[string]*pluginsdk.Schema{
+					"url": {
+						Type:         pluginsdk.TypeString,
+						Required:     true,
+						ValidateFunc: validation.IsURLWithHTTPorHTTPS,
+					},
+
+					"bucket_name": {
+						Type:         pluginsdk.TypeString,
+						Required:     true,
+						ValidateFunc: validation.StringLenBetween(3, 63),
+					},
+
+					"access_key": {
+						Type:          pluginsdk.TypeString,
+						Optional:      true,
+						ValidateFunc:  validation.StringIsNotEmpty,
+						RequiredWith:  []string{"bucket.0.secret_key_base64"},
+						ConflictsWith: []string{"bucket.0.local_auth_reference"},
+					},
+
+					"secret_key_base64": {
+						Type:         pluginsdk.TypeString,
+						Optional:     true,
+						Sensitive:    true,
+						ValidateFunc: validation.StringIsBase64,
+						RequiredWith: []string{"bucket.0.access_key"},
+					},
+
+					"tls_enabled": {
+						Type:     pluginsdk.TypeBool,
+						Optional: true,
+						Default:  true,
+					},
+
+					"local_auth_reference": {
+						Type:          pluginsdk.TypeString,
+						Optional:      true,
+						ValidateFunc:  validateKubernetesFluxName,
+						ConflictsWith: []string{"bucket.0.access_key"},
+					},
+
+					"sync_interval_in_seconds": syncInterval(),
+
+					"timeout_in_seconds": timeout(),
+				},
+			},
+		},
+
+		"configuration_protected_settings": {
+			Type:      pluginsdk.TypeMap,
+			Optional:  true,
+			Sensitive: true,
+			Elem: &pluginsdk.Schema{
+				Type:         pluginsdk.TypeString,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+		},
+
+		"scope": {
+			Type:     pluginsdk.TypeString,
+			Optional: true,
+			ForceNew: true,
+			Default:  string(fluxconfiguration.ScopeTypeNamespace),
+			ValidateFunc: validation.StringInSlice([]string{
+				string(fluxconfiguration.ScopeTypeCluster),
+				string(fluxconfiguration.ScopeTypeNamespace),
+			}, false),
+		},
+
+		"continuous_reconciliation_enabled": {
+			Type:     pluginsdk.TypeBool,
+			Optional: true,
+			Default:  true,
+		},
+
+		"wait_for_compliance": {
+			Type:     pluginsdk.TypeBool,
+			Optional: true,
+			Default:  true,
+		},
+	}
+}
+
+func (r KubernetesFluxConfigurationResource) Attributes() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"compliance_state": {
+			Type:     pluginsdk.TypeString,
+			Computed: true,
+		},
+	}
+}
+
+func (r KubernetesFluxConfigurationResource) Create() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 30 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			var model KubernetesFluxConfigurationModel
+			if err := metadata.Decode(&model); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			client := metadata.Client.Containers.KubernetesFluxConfigurationClient
+			clusterId, err := managedclusters.ParseManagedClusterID(model.ClusterID)
+			if err != nil {
+				return err
+			}
+
+			id := fluxconfiguration.NewFluxConfigurationID(clusterId.SubscriptionId, clusterId.ResourceGroupName, "Microsoft.ContainerService", "managedClusters", clusterId.ManagedClusterName, model.Name)
+
+			existing, err := client.Get(ctx, id)
+			if err != nil && !response.WasNotFound(existing.HttpResponse) {
+				return fmt.Errorf("checking for existing %s: %+v", id, err)
+			}
+
+			if !response.WasNotFound(existing.HttpResponse) {
+				return metadata.ResourceRequiresImport(r.ResourceType(), id)
+			}
+
+			scope := fluxconfiguration.ScopeType(model.Scope)
+			properties := fluxconfiguration.FluxConfiguration{
+				Properties: &fluxconfiguration.FluxConfigurationProperties{
+					Kustomizations: expandKustomizationDefinitionModel(model.Kustomizations),
+					Namespace:      &model.Namespace,
+					Scope:          &scope,
+					Suspend:        utils.Bool(!model.ContinuousReconciliationEnabled),
+				},
+			}
+
+			protectedSettings := model.ConfigurationProtectedSettings
+			if protectedSettings == nil {
+				protectedSettings = make(map[string]string)
+			}
+
+			if len(model.GitRepository) > 0 {
+				gitRepository, err := expandGitRepositoryDefinitionModel(model.GitRepository, protectedSettings)
+				if err != nil {
+					return err
+				}
+
+				properties.Properties.SourceKind = sourceKindPointer(fluxconfiguration.SourceKindTypeGitRepository)
+				properties.Properties.GitRepository = gitRepository
+			} else if len(model.Bucket) > 0 {
+				properties.Properties.SourceKind = sourceKindPointer(fluxconfiguration.SourceKindTypeBucket)
+				properties.Properties.Bucket = expandBucketDefinitionModel(model.Bucket, protectedSettings)
+			}
+
+			properties.Properties.ConfigurationProtectedSettings = &protectedSettings
+
+			if err := client.CreateOrUpdateThenPoll(ctx, id, properties); err != nil {
+				return fmt.Errorf("creating %s: %+v", id, err)
+			}
+
+			metadata.SetID(id)
+
+			if model.WaitForCompliance {
+				if err := waitForKubernetesFluxConfigurationCompliance(ctx, client, id, nil); err != nil {
+					return err
+				}
+			}
+
+			return nil
+		},
+	}
+}
+
+func (r KubernetesFluxConfigurationResource) Update() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 30 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.Containers.KubernetesFluxConfigurationClient
+
+			id, err := fluxconfiguration.ParseFluxConfigurationID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			var model KubernetesFluxConfigurationModel
+			if err := metadata.Decode(&model); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			resp, err := client.Get(ctx, *id)
+			if err != nil {
+				return fmt.Errorf("retrieving %s: %+v", *id, err)
+			}
+
+			properties := resp.Model
+			if properties == nil || properties.Properties == nil {
+				return fmt.Errorf("retrieving %s: `properties` was nil", *id)
+			}
+
+			// the API doesn't return the protected settings - so these always need to be sent
+			protectedSettings := model.ConfigurationProtectedSettings
+			if protectedSettings == nil {
+				protectedSettings = make(map[string]string)
+			}
+
+			if len(model.GitRepository) > 0 {
+				gitRepository, err := expandGitRepositoryDefinitionModel(model.GitRepository, protectedSettings)
+				if err != nil {
+					return err
+				}
+
+				properties.Properties.SourceKind = sourceKindPointer(fluxconfiguration.SourceKindTypeGitRepository)
+				properties.Properties.GitRepository = gitRepository
+				properties.Properties.Bucket = nil
+			} else if len(model.Bucket) > 0 {
+				properties.Properties.SourceKind = sourceKindPointer(fluxconfiguration.SourceKindTypeBucket)
+				properties.Properties.Bucket = expandBucketDefinitionModel(model.Bucket, protectedSettings)
+				properties.Properties.GitRepository = nil
+			}
+
+			properties.Properties.ConfigurationProtectedSettings = &protectedSettings
+
+			// the compliance state from before this update remains until the agent reports on the updated configuration
+			previousStatusUpdatedAt, err := properties.Properties.GetStatusUpdatedAtAsTime()
+			if err != nil {
+				return fmt.Errorf("parsing `statusUpdatedAt` for %s: %+v", *id, err)
+			}
+
+			if metadata.ResourceData.HasChange("kustomizations") {
+				properties.Properties.Kustomizations = expandKustomizationDefinitionModel(model.Kustomizations)
+			}
+
+			if metadata.ResourceData.HasChange("continuous_reconciliation_enabled") {
+				properties.Properties.Suspend = utils.Bool(!model.ContinuousReconciliationEnabled)
+			}
+
+			// these are read-only and are rejected by the API when they're sent
+			properties.Properties.ComplianceState = nil
+			properties.Properties.ErrorMessage = nil
+			properties.Properties.ProvisioningState = nil
+			properties.Properties.RepositoryPublicKey = nil
+			properties.Properties.SourceSyncedCommitId = nil
+			properties.Properties.SourceUpdatedAt = nil
+			properties.Properties.StatusUpdatedAt = nil
+			properties.Properties.Statuses = nil
+
+			if err := client.CreateOrUpdateThenPoll(ctx, *id, *properties); err != nil {
+				return fmt.Errorf("updating %s: %+v", *id, err)
+			}
+
+			if model.WaitForCompliance {
+				if err := waitForKubernetesFluxConfigurationCompliance(ctx, client, *id, previousStatusUpdatedAt); err != nil {
+					return err
+				}
+			}
+
+			return nil
+		},
+	}
+}
+
+func (r KubernetesFluxConfigurationResource) Read() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 5 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.Containers.KubernetesFluxConfigurationClient
+
+			id, err := fluxconfiguration.ParseFluxConfigurationID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			resp, err := client.Get(ctx, *id)
+			if err != nil {
+				if response.WasNotFound(resp.HttpResponse) {
+					return metadata.MarkAsGone(id)
+				}
+
+				return fmt.Errorf("retrieving %s: %+v", *id, err)
+			}
+
+			var state KubernetesFluxConfigurationModel
+			if err := metadata.Decode(&state); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			model := KubernetesFluxConfigurationModel{
+				Name:      id.FluxConfigurationName,
+				ClusterID: managedclusters.NewManagedClusterID(id.SubscriptionId, id.ResourceGroupName, id.ClusterName).ID(),
+				// the API doesn't return the protected settings, so these are pulled from the state
+				ConfigurationProtectedSettings: state.ConfigurationProtectedSettings,
+				WaitForCompliance:              state.WaitForCompliance,
+			}
+
+			if respModel := resp.Model; respModel != nil {
+				if props := respModel.Properties; props != nil {
+					model.Bucket = flattenBucketDefinitionModel(props.Bucket, state.Bucket)
+					model.GitRepository = flattenGitRepositoryDefinitionModel(props.GitRepository, state.GitRepository)
+					model.Kustomizations = flattenKustomizationDefinitionModel(props.Kustomizations)
+
+					if props.ComplianceState != nil {
+						model.ComplianceState = string(*props.ComplianceState)
+					}
+
+					if props.Namespace != nil {
+						model.Namespace = *props.Namespace
+					}
+
+					if props.Scope != nil {
+						model.Scope = string(*props.Scope)
+					}
+
+					model.ContinuousReconciliationEnabled = true
+					if props.Suspend != nil {
+						model.ContinuousReconciliationEnabled = !*props.Suspend
+					}
+				}
+			}
+
+			return metadata.Encode(&model)
+		},
+	}
+}
+
+func (r KubernetesFluxConfigurationResource) Delete() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 30 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.Containers.KubernetesFluxConfigurationClient
+
+			id, err := fluxconfiguration.ParseFluxConfigurationID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			if err := client.DeleteThenPoll(ctx, *id, fluxconfiguration.DeleteOperationOptions{}); err != nil {
+				return fmt.Errorf("deleting %s: %+v", *id, err)
+			}
+
+			return nil
+		},
+	}
+}
+
+// waitForKubernetesFluxConfigurationCompliance waits for the Flux Configuration to finish reconciling the source and
+// kustomizations onto the Kubernetes Cluster - which happens asynchronously once the resource has been provisioned.
+// When previousStatusUpdatedAt is specified, any compliance state reported at or before that time is ignored. Once the
+// compliance state has settled, a Non-Compliant Flux Configuration is returned as an error.
+func waitForKubernetesFluxConfigurationCompliance(ctx context.Context, client *fluxconfiguration.FluxConfigurationClient, id fluxconfiguration.FluxConfigurationId, previousStatusUpdatedAt *time.Time) error {
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		return fmt.Errorf("internal-error: context had no deadline")
+	}
+
+	stateConf := &pluginsdk.StateChangeConf{
+		Pending: []string{
+			string(fluxconfiguration.FluxComplianceStatePending),
+			string(fluxconfiguration.FluxComplianceStateUnknown),
+		},
+		Target: []string{
+			string(fluxconfiguration.FluxComplianceStateCompliant),
+			string(fluxconfiguration.FluxComplianceStateNonNegativeCompliant),
+			string(fluxconfiguration.FluxComplianceStateSuspended),
+		},
+		Refresh: func() (interface{}, string, error) {
+			resp, err := client.Get(ctx, id)
+			if err != nil {
+				return nil, "", fmt.Errorf("retrieving %s: %+v", id, err)
+			}
+
+			if resp.Model == nil || resp.Model.Properties == nil || resp.Model.Properties.ComplianceState == nil {
+				return resp, string(fluxconfiguration.FluxComplianceStateUnknown), nil
+			}
+
+			if previousStatusUpdatedAt != nil {
+				statusUpdatedAt, err := resp.Model.Properties.GetStatusUpdatedAtAsTime()
+				if err != nil {
+					return nil, "", fmt.Errorf("parsing `statusUpdatedAt` for %s: %+v", id, err)
+				}
+				if statusUpdatedAt == nil || !statusUpdatedAt.After(*previousStatusUpdatedAt) {
+					return resp, string(fluxconfiguration.FluxComplianceStatePending), nil
+				}
+			}
+
+			return resp, string(*resp.Model.Properties.ComplianceState), nil
+		},
+		MinTimeout:                10 * time.Second,
+		ContinuousTargetOccurence: 2,
+		Timeout:                   time.Until(deadline),
+	}
+
+	result, err := stateConf.WaitForStateContext(ctx)
+	if err != nil {
+		return fmt.Errorf("waiting for %s to become Compliant: %+v", id, err)
+	}
+
+	if resp, ok := result.(fluxconfiguration.GetOperationResponse); ok && resp.Model != nil && resp.Model.Properties != nil {
+		props := resp.Model.Properties
+		if props.ComplianceState != nil && *props.ComplianceState == fluxconfiguration.FluxComplianceStateNonNegativeCompliant {
+			return fmt.Errorf("%s is %s: %s", id, string(*props.ComplianceState), flattenKubernetesFluxConfigurationNonCompliantDetails(props))
+		}
+	}
+
+	return nil
+}
+
+// flattenKubernetesFluxConfigurationNonCompliantDetails returns the error message and the status conditions of the
+// objects which aren't Compliant, so that the reason a Flux Configuration is Non-Compliant can be surfaced
+func flattenKubernetesFluxConfigurationNonCompliantDetails(input *fluxconfiguration.FluxConfigurationProperties) string {
+	details := make([]string, 0)
+	if input.ErrorMessage != nil && *input.ErrorMessage != "" {
+		details = append(details, *input.ErrorMessage)
+	}
+
+	if input.Statuses != nil {
+		for _, status := range *input.Statuses {
+			if status.ComplianceState == nil || *status.ComplianceState != fluxconfiguration.FluxComplianceStateNonNegativeCompliant || status.StatusConditions == nil {
+				continue
+			}
+
+			for _, condition := range *status.StatusConditions {
+				if condition.Message == nil || *condition.Message == "" {
+					continue
+				}
+				details = append(details, fmt.Sprintf("%s %q: %s", utils.NormalizeNilableString(status.Kind), utils.NormalizeNilableString(status.Name), *condition.Message))
+			}
+		}
+	}
+
+	if len(details) == 0 {
+		return "no further details were returned"
+	}
+
+	return strings.Join(details, "; ")
+}
+
+func expandKustomizationDefinitionModel(inputList []KustomizationDefinitionModel) *map[string]fluxconfiguration.KustomizationDefinition {
+	outputList := make(map[string]fluxconfiguration.KustomizationDefinition)
+	for _, v := range inputList {
+		input := v
+		output := fluxconfiguration.KustomizationDefinition{
+			DependsOn:              &input.DependsOn,
+			Force:                  &input.RecreatingEnabled,
+			Name:                   &input.Name,
+			Prune:                  &input.GarbageCollectionEnabled,
+			RetryIntervalInSeconds: &input.RetryIntervalInSeconds,
+			SyncIntervalInSeconds:  &input.SyncIntervalInSeconds,
+			TimeoutInSeconds:       &input.TimeoutInSeconds,
+		}
+
+		if input.Path != "" {
+			output.Path = &input.Path
+		}
+
+		outputList[input.Name] = output
+	}
+
+	return &outputList
+}
+
+func expandGitRepositoryDefinitionModel(inputList []GitRepositoryDefinitionModel, protectedSettings map[string]string) (*fluxconfiguration.GitRepositoryDefinition, error) {
+	if len(inputList) == 0 {
+		return nil, nil
+	}
+
+	input := &inputList[0]
+	output := fluxconfiguration.GitRepositoryDefinition{
+		RepositoryRef:         &fluxconfiguration.RepositoryRefDefinition{},
+		SyncIntervalInSeconds: &input.SyncIntervalInSeconds,
+		TimeoutInSeconds:      &input.TimeoutInSeconds,
+		Url:                   &input.Url,
+	}
+
+	if input.HttpsCACertBase64 != "" {
+		output.HTTPSCACert = &input.HttpsCACertBase64
+	}
+
+	if input.HttpsUser != "" {
+		output.HTTPSUser = &input.HttpsUser
+	}
+
+	if input.HttpsKeyBase64 != "" {
+		protectedSettings[fluxProtectedSettingHttpsKey] = input.HttpsKeyBase64
+	}
+
+	if input.LocalAuthReference != "" {
+		output.LocalAuthRef = &input.LocalAuthReference
+	}
+
+	if input.SshKnownHostsBase64 != "" {
+		output.SshKnownHosts = &input.SshKnownHostsBase64
+	}
+
+	if input.SshPrivateKeyBase64 != "" {
+		protectedSettings[fluxProtectedSettingSshPrivateKey] = input.SshPrivateKeyBase64
+	}
+
+	switch input.ReferenceType {
+	case fluxReferenceTypeBranch:
+		output.RepositoryRef.Branch = &input.ReferenceValue
+	case fluxReferenceTypeCommit:
+		output.RepositoryRef.Commit = &input.ReferenceValue
+	case fluxReferenceTypeSemver:
+		output.RepositoryRef.Semver = &input.ReferenceValue
+	case fluxReferenceTypeTag:
+		output.RepositoryRef.Tag = &input.ReferenceValue
+	default:
+		return nil, fmt.Errorf("reference type %q is not supported", input.ReferenceType)
+	}
+
+	return &output, nil
+}
+
+func expandBucketDefinitionModel(inputList []BucketDefinitionModel, protectedSettings map[string]string) *fluxconfiguration.BucketDefinition {
+	if len(inputList) == 0 {
+		return nil
+	}
+
+	input := &inputList[0]
+	output := fluxconfiguration.BucketDefinition{
+		BucketName:            &input.BucketName,
+		Insecure:              utils.Bool(!input.TlsEnabled),
+		SyncIntervalInSeconds: &input.SyncIntervalInSeconds,
+		TimeoutInSeconds:      &input.TimeoutInSeconds,
+		Url:                   &input.Url,
+	}
+
+	if input.AccessKey != "" {
+		output.AccessKey = &input.AccessKey
+	}
+
+	if input.SecretKeyBase64 != "" {
+		protectedSettings[fluxProtectedSettingBucketSecret] = input.SecretKeyBase64
+	}
+
+	if input.LocalAuthReference != "" {
+		output.LocalAuthRef = &input.LocalAuthReference
+	}
+
+	return &output
+}
+
+func flattenKustomizationDefinitionModel(inputList *map[string]fluxconfiguration.KustomizationDefinition) []KustomizationDefinitionModel {
+	outputList := make([]KustomizationDefinitionModel, 0)
+	if inputList == nil {
+		return outputList
+	}
+
+	for name, input := range *inputList {
+		output := KustomizationDefinitionModel{
+			Name: name,
+		}
+
+		if input.DependsOn != nil {
+			output.DependsOn = *input.DependsOn
+		}
+
+		if input.Force != nil {
+			output.RecreatingEnabled = *input.Force
+		}
+
+		if input.Path != nil {
+			output.Path = *input.Path
+		}
+
+		if input.Prune != nil {
+			output.GarbageCollectionEnabled = *input.Prune
+		}
+
+		if input.RetryIntervalInSeconds != nil {
+			output.RetryIntervalInSeconds = *input.RetryIntervalInSeconds
+		}
+
+		if input.SyncIntervalInSeconds != nil {
+			output.SyncIntervalInSeconds = *input.SyncIntervalInSeconds
+		}
+
+		if input.TimeoutInSeconds != nil {
+			output.TimeoutInSeconds = *input.TimeoutInSeconds
+		}
+
+		outputList = append(outputList, output)
+	}
+
+	return outputList
+}
+
+func flattenGitRepositoryDefinitionModel(input *fluxconfiguration.GitRepositoryDefinition, state []GitRepositoryDefinitionModel) []GitRepositoryDefinitionModel {
+	if input == nil {
+		return make([]GitRepositoryDefinitionModel, 0)
+	}
+
+	output := GitRepositoryDefinitionModel{}
+
+	// the credentials are stored within the protected settings, which aren't returned by the API
+	if len(state) > 0 {
+		output.HttpsKeyBase64 = state[0].HttpsKeyBase64
+		output.SshPrivateKeyBase64 = state[0].SshPrivateKeyBase64
+	}
+
+	if input.HTTPSCACert != nil {
+		output.HttpsCACertBase64 = *input.HTTPSCACert
+	}
+
+	if input.HTTPSUser != nil {
+		output.HttpsUser = *input.HTTPSUser
+	}
+
+	if input.LocalAuthRef != nil {
+		output.LocalAuthReference = *input.LocalAuthRef
+	}
+
+	if ref := input.RepositoryRef; ref != nil {
+		if ref.Branch != nil {
+			output.ReferenceType = fluxReferenceTypeBranch
+			output.ReferenceValue = *ref.Branch
+		} else if ref.Commit != nil {
+			output.ReferenceType = fluxReferenceTypeCommit
+			output.ReferenceValue = *ref.Commit
+		} else if ref.Semver != nil {
+			output.ReferenceType = fluxReferenceTypeSemver
+			output.ReferenceValue = *ref.Semver
+		} else if ref.Tag != nil {
+			output.ReferenceType = fluxReferenceTypeTag
+			output.ReferenceValue = *ref.Tag
+		}
+	}
+
+	if input.SshKnownHosts != nil {
+		output.SshKnownHostsBase64 = *input.SshKnownHosts
+	}
+
+	if input.SyncIntervalInSeconds != nil {
+		output.SyncIntervalInSeconds = *input.SyncIntervalInSeconds
+	}
+
+	if input.TimeoutInSeconds != nil {
+		output.TimeoutInSeconds = *input.TimeoutInSeconds
+	}
+
+	if input.Url != nil {
+		output.Url = *input.Url
+	}
+
+	return []GitRepositoryDefinitionModel{output}
+}
+
+func flattenBucketDefinitionModel(input *fluxconfiguration.BucketDefinition, state []BucketDefinitionModel) []BucketDefinitionModel {
+	if input == nil {
+		return make([]BucketDefinitionModel, 0)
+	}
+
+	output := BucketDefinitionModel{
+		TlsEnabled: true,
+	}
+
+	// the secret key is stored within the protected settings, which aren't returned by the API
+	if len(state) > 0 {
+		output.SecretKeyBase64 = state[0].SecretKeyBase64
+	}
+
+	if input.AccessKey != nil {
+		output.AccessKey = *input.AccessKey
+	}
+
+	if input.BucketName != nil {
+		output.BucketName = *input.BucketName
+	}
+
+	if input.Insecure != nil {
+		output.TlsEnabled = !*input.Insecure
+	}
+
+	if input.LocalAuthRef != nil {
+		output.LocalAuthReference = *input.LocalAuthRef
+	}
+
+	if input.SyncIntervalInSeconds != nil {
+		output.SyncIntervalInSeconds = *input.SyncIntervalInSeconds
+	}
+
+	if input.TimeoutInSeconds != nil {
+		output.TimeoutInSeconds = *input.TimeoutInSeconds
+	}
+
+	if input.Url != nil {
+		output.Url = *input.Url
+	}
+
+	return []BucketDefinitionModel{output}
+}
+
+func sourceKindPointer(input fluxconfiguration.SourceKindType) *fluxconfiguration.SourceKindType {
+	return &input
+}
+
+func validateKubernetesFluxName(i interface{}, k string) ([]string, []error) {
+	v, ok := i.(string)
+	if !ok {
+		return nil, []error{fmt.Errorf("expected type of %q to be string", k)}
+	}
+
+	if len(v) > 63 {
+		return nil, []error{fmt.Errorf("%q must be at most 63 characters", k)}
+	}
+
+	if !regexp.MustCompile(`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`).MatchString(v) || strings.Contains(v, "--") {
+		return nil, []error{fmt.Errorf("%q must contain only lowercase alphanumeric characters or single hyphens, and must start and end with an alphanumeric character", k)}
+	}
+
+	return nil, nil
+}
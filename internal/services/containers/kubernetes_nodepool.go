@@ -13,6 +13,7 @@ import (
 	"github.com/hashicorp/go-azure-sdk/resource-manager/compute/2022-03-01/proximityplacementgroups"
 	"github.com/hashicorp/go-azure-sdk/resource-manager/containerservice/2022-09-02-preview/agentpools"
 	"github.com/hashicorp/go-azure-sdk/resource-manager/containerservice/2022-09-02-preview/managedclusters"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/containerservice/2022-09-02-preview/snapshots"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-provider-azurerm/helpers/azure"
 	computeValidate "github.com/hashicorp/terraform-provider-azurerm/internal/services/compute/validate"
@@ -53,7 +54,6 @@ func SchemaDefaultNodePool() *pluginsdk.Schema {
 					"vm_size": {
 						Type:         pluginsdk.TypeString,
 						Required:     true,
-						ForceNew:     true,
 						ValidateFunc: validation.StringIsNotEmpty,
 					},
 
@@ -79,14 +79,12 @@ func SchemaDefaultNodePool() *pluginsdk.Schema {
 					"enable_node_public_ip": {
 						Type:     pluginsdk.TypeBool,
 						Optional: true,
-						ForceNew: true,
 					},
 
 					// TODO 4.0: change this from enable_* to *_enabled
 					"enable_host_encryption": {
 						Type:     pluginsdk.TypeBool,
 						Optional: true,
-						ForceNew: true,
 					},
 
 					"kubelet_config": schemaNodePoolKubeletConfig(),
@@ -96,7 +94,6 @@ func SchemaDefaultNodePool() *pluginsdk.Schema {
 					"fips_enabled": {
 						Type:     pluginsdk.TypeBool,
 						Optional: true,
-						ForceNew: true,
 					},
 
 					"kubelet_disk_type": {
@@ -120,7 +117,6 @@ func SchemaDefaultNodePool() *pluginsdk.Schema {
 						Type:     pluginsdk.TypeInt,
 						Optional: true,
 						Computed: true,
-						ForceNew: true,
 					},
 
 					"message_of_the_day": {
@@ -174,10 +170,15 @@ func SchemaDefaultNodePool() *pluginsdk.Schema {
 
 					"tags": commonschema.Tags(),
 
+					"temporary_name_for_rotation": {
+						Type:         pluginsdk.TypeString,
+						Optional:     true,
+						ValidateFunc: validate.KubernetesAgentPoolName,
+					},
+
 					"os_disk_size_gb": {
 						Type:         pluginsdk.TypeInt,
 						Optional:     true,
-						ForceNew:     true,
 						Computed:     true,
 						ValidateFunc: validation.IntAtLeast(1),
 					},
@@ -185,7 +186,6 @@ func SchemaDefaultNodePool() *pluginsdk.Schema {
 					"os_disk_type": {
 						Type:     pluginsdk.TypeString,
 						Optional: true,
-						ForceNew: true,
 						Default:  agentpools.OSDiskTypeManaged,
 						ValidateFunc: validation.StringInSlice([]string{
 							string(managedclusters.OSDiskTypeEphemeral),
@@ -196,7 +196,6 @@ func SchemaDefaultNodePool() *pluginsdk.Schema {
 					"os_sku": {
 						Type:     pluginsdk.TypeString,
 						Optional: true,
-						ForceNew: true,
 						Computed: true, // defaults to Ubuntu if using Linux
 						ValidateFunc: validation.StringInSlice([]string{
 							string(agentpools.OSSKUCBLMariner),
@@ -209,7 +208,6 @@ func SchemaDefaultNodePool() *pluginsdk.Schema {
 
 					"ultra_ssd_enabled": {
 						Type:     pluginsdk.TypeBool,
-						ForceNew: true,
 						Default:  false,
 						Optional: true,
 					},
@@ -217,7 +215,6 @@ func SchemaDefaultNodePool() *pluginsdk.Schema {
 					"vnet_subnet_id": {
 						Type:         pluginsdk.TypeString,
 						Optional:     true,
-						ForceNew:     true,
 						ValidateFunc: azure.ValidateResourceID,
 					},
 					"orchestrator_version": {
@@ -229,7 +226,6 @@ func SchemaDefaultNodePool() *pluginsdk.Schema {
 					"pod_subnet_id": {
 						Type:         pluginsdk.TypeString,
 						Optional:     true,
-						ForceNew:     true,
 						ValidateFunc: networkValidate.SubnetID,
 					},
 					"proximity_placement_group_id": {
@@ -241,7 +237,6 @@ func SchemaDefaultNodePool() *pluginsdk.Schema {
 					"only_critical_addons_enabled": {
 						Type:     pluginsdk.TypeBool,
 						Optional: true,
-						ForceNew: true,
 					},
 
 					"scale_down_mode": {
@@ -254,6 +249,12 @@ func SchemaDefaultNodePool() *pluginsdk.Schema {
 						}, false),
 					},
 
+					"snapshot_id": {
+						Type:         pluginsdk.TypeString,
+						Optional:     true,
+						ValidateFunc: snapshots.ValidateSnapshotID,
+					},
+
 					"host_group_id": {
 						Type:         pluginsdk.TypeString,
 						Optional:     true,
@@ -273,7 +274,7 @@ func SchemaDefaultNodePool() *pluginsdk.Schema {
 					},
 				}
 
-				s["zones"] = commonschema.ZonesMultipleOptionalForceNew()
+				s["zones"] = commonschema.ZonesMultipleOptional()
 
 				return s
 			}(),
@@ -677,6 +678,11 @@ func ConvertDefaultNodePoolToAgentPool(input *[]managedclusters.ManagedClusterAg
 			Tags:                      defaultCluster.Tags,
 		},
 	}
+	if creationDataNodePool := defaultCluster.CreationData; creationDataNodePool != nil {
+		agentpool.Properties.CreationData = &agentpools.CreationData{
+			SourceResourceId: creationDataNodePool.SourceResourceId,
+		}
+	}
 	if osDisktypeNodePool := defaultCluster.OsDiskType; osDisktypeNodePool != nil {
 		osDisktype := agentpools.OSDiskType(string(*osDisktypeNodePool))
 		agentpool.Properties.OsDiskType = &osDisktype
@@ -875,6 +881,12 @@ func ExpandDefaultNodePool(d *pluginsdk.ResourceData) (*[]managedclusters.Manage
 		profile.CapacityReservationGroupID = utils.String(capacityReservationGroupId)
 	}
 
+	if snapshotId := raw["snapshot_id"].(string); snapshotId != "" {
+		profile.CreationData = &managedclusters.CreationData{
+			SourceResourceId: utils.String(snapshotId),
+		}
+	}
+
 	count := raw["node_count"].(int)
 	maxCount := raw["max_count"].(int)
 	minCount := raw["min_count"].(int)
@@ -1274,6 +1286,15 @@ func FlattenDefaultNodePool(input *[]managedclusters.ManagedClusterAgentPoolProf
 		agentPoolType = string(*agentPool.Type)
 	}
 
+	snapshotId := ""
+	if agentPool.CreationData != nil && agentPool.CreationData.SourceResourceId != nil {
+		parsedSnapshotId, err := snapshots.ParseSnapshotIDInsensitively(*agentPool.CreationData.SourceResourceId)
+		if err != nil {
+			return nil, err
+		}
+		snapshotId = parsedSnapshotId.ID()
+	}
+
 	upgradeSettings := flattenClusterNodePoolUpgradeSettings(agentPool.UpgradeSettings)
 	linuxOSConfig, err := flattenClusterNodePoolLinuxOSConfig(agentPool.LinuxOSConfig)
 	if err != nil {
@@ -1304,7 +1325,9 @@ func FlattenDefaultNodePool(input *[]managedclusters.ManagedClusterAgentPoolProf
 		"os_disk_type":                  string(osDiskType),
 		"os_sku":                        osSKU,
 		"scale_down_mode":               string(scaleDownMode),
+		"snapshot_id":                   snapshotId,
 		"tags":                          tags.Flatten(agentPool.Tags),
+		"temporary_name_for_rotation":   d.Get("default_node_pool.0.temporary_name_for_rotation").(string),
 		"type":                          agentPoolType,
 		"ultra_ssd_enabled":             enableUltraSSD,
 		"vm_size":                       vmSize,
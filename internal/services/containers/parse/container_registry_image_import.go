@@ -0,0 +1,75 @@
+package parse
+
+// NOTE: this file is generated via 'go:generate' - manual changes will be overwritten
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/resourceids"
+)
+
+type ContainerRegistryImageImportId struct {
+	SubscriptionId string
+	ResourceGroup  string
+	RegistryName   string
+	ImportName     string
+}
+
+func NewContainerRegistryImageImportID(subscriptionId, resourceGroup, registryName, importName string) ContainerRegistryImageImportId {
+	return ContainerRegistryImageImportId{
+		SubscriptionId: subscriptionId,
+		ResourceGroup:  resourceGroup,
+		RegistryName:   registryName,
+		ImportName:     importName,
+	}
+}
+
+func (id ContainerRegistryImageImportId) String() string {
+	segments := []string{
+		fmt.Sprintf("Import Name %q", id.ImportName),
+		fmt.Sprintf("Registry Name %q", id.RegistryName),
+		fmt.Sprintf("Resource Group %q", id.ResourceGroup),
+	}
+	segmentsStr := strings.Join(segments, " / ")
+	return fmt.Sprintf("%s: (%s)", "Container Registry Image Import", segmentsStr)
+}
+
+func (id ContainerRegistryImageImportId) ID() string {
+	fmtString := "/subscriptions/%s/resourceGroups/%s/providers/Microsoft.ContainerRegistry/registries/%s/imports/%s"
+	return fmt.Sprintf(fmtString, id.SubscriptionId, id.ResourceGroup, id.RegistryName, id.ImportName)
+}
+
+// ContainerRegistryImageImportID parses a ContainerRegistryImageImport ID into an ContainerRegistryImageImportId struct
+func ContainerRegistryImageImportID(input string) (*ContainerRegistryImageImportId, error) {
+	id, err := resourceids.ParseAzureResourceID(input)
+	if err != nil {
+		return nil, err
+	}
+
+	resourceId := ContainerRegistryImageImportId{
+		SubscriptionId: id.SubscriptionID,
+		ResourceGroup:  id.ResourceGroup,
+	}
+
+	if resourceId.SubscriptionId == "" {
+		return nil, fmt.Errorf("ID was missing the 'subscriptions' element")
+	}
+
+	if resourceId.ResourceGroup == "" {
+		return nil, fmt.Errorf("ID was missing the 'resourceGroups' element")
+	}
+
+	if resourceId.RegistryName, err = id.PopSegment("registries"); err != nil {
+		return nil, err
+	}
+	if resourceId.ImportName, err = id.PopSegment("imports"); err != nil {
+		return nil, err
+	}
+
+	if err := id.ValidateNoEmptySegments(input); err != nil {
+		return nil, err
+	}
+
+	return &resourceId, nil
+}
@@ -58,10 +58,16 @@ func (r Registration) DataSources() []sdk.DataSource {
 
 func (r Registration) Resources() []sdk.Resource {
 	resources := []sdk.Resource{
+		ContainerRegistryImageImportResource{},
 		ContainerRegistryTaskResource{},
 		ContainerRegistryTaskScheduleResource{},
 		ContainerRegistryTokenPasswordResource{},
 		ContainerConnectedRegistryResource{},
+		KubernetesClusterCommandResource{},
+		KubernetesClusterExtensionResource{},
+		KubernetesClusterNodePoolSnapshotResource{},
+		KubernetesFleetMemberResource{},
+		KubernetesFluxConfigurationResource{},
 	}
 	resources = append(resources, r.autoRegistration.Resources()...)
 	return resources
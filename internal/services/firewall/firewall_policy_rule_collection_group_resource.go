@@ -415,6 +415,8 @@ func resourceFirewallPolicyRuleCollectionGroup() *pluginsdk.Resource {
 				},
 			},
 		},
+
+		CustomizeDiff: pluginsdk.CustomizeDiffShim(firewallPolicyRuleCollectionGroupCustomizeDiff),
 	}
 }
 
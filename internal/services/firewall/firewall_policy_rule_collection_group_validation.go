@@ -0,0 +1,103 @@
+package firewall
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+)
+
+type firewallPolicyRuleCollection struct {
+	blockName string
+	name      string
+	priority  int
+	rules     []map[string]interface{}
+}
+
+// firewallPolicyRuleCollectionGroupCustomizeDiff analyses the Rule Collections and Rules within this Rule Collection Group
+// at plan time, to surface conflicts which the API would otherwise reject part-way through an apply (or silently accept).
+func firewallPolicyRuleCollectionGroupCustomizeDiff(ctx context.Context, d *pluginsdk.ResourceDiff, _ interface{}) error {
+	collections := make([]firewallPolicyRuleCollection, 0)
+	for _, blockName := range []string{"application_rule_collection", "network_rule_collection", "nat_rule_collection"} {
+		// the values can't be evaluated until they're known, which happens during the apply
+		if raw := d.GetRawConfig(); !raw.IsNull() && !raw.GetAttr(blockName).IsWhollyKnown() {
+			return nil
+		}
+
+		for _, item := range d.Get(blockName).([]interface{}) {
+			if item == nil {
+				continue
+			}
+			v := item.(map[string]interface{})
+
+			collection := firewallPolicyRuleCollection{
+				blockName: blockName,
+				name:      v["name"].(string),
+				priority:  v["priority"].(int),
+				rules:     make([]map[string]interface{}, 0),
+			}
+			for _, rule := range v["rule"].([]interface{}) {
+				if rule == nil {
+					continue
+				}
+				collection.rules = append(collection.rules, rule.(map[string]interface{}))
+			}
+
+			collections = append(collections, collection)
+		}
+	}
+
+	if err := validateFirewallPolicyRuleCollectionsAreUnique(collections); err != nil {
+		return err
+	}
+
+	for _, collection := range collections {
+		if err := validateFirewallPolicyRulesInCollection(collection); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func validateFirewallPolicyRuleCollectionsAreUnique(collections []firewallPolicyRuleCollection) error {
+	names := make(map[string]firewallPolicyRuleCollection)
+	priorities := make(map[int]firewallPolicyRuleCollection)
+	for _, collection := range collections {
+		if existing, ok := names[strings.ToLower(collection.name)]; ok {
+			return fmt.Errorf("the `%s` %q and the `%s` %q have the same name - Rule Collection names must be unique within a Rule Collection Group", existing.blockName, existing.name, collection.blockName, collection.name)
+		}
+		names[strings.ToLower(collection.name)] = collection
+
+		if existing, ok := priorities[collection.priority]; ok {
+			return fmt.Errorf("the `%s` %q and the `%s` %q have the same priority (%d) - Rule Collection priorities must be unique within a Rule Collection Group", existing.blockName, existing.name, collection.blockName, collection.name, collection.priority)
+		}
+		priorities[collection.priority] = collection
+	}
+
+	return nil
+}
+
+func validateFirewallPolicyRulesInCollection(collection firewallPolicyRuleCollection) error {
+	names := make(map[string]struct{})
+	for _, rule := range collection.rules {
+		name := rule["name"].(string)
+		if _, ok := names[strings.ToLower(name)]; ok {
+			return fmt.Errorf("the `%s` %q contains more than one rule named %q - rule names must be unique within a Rule Collection", collection.blockName, collection.name, name)
+		}
+		names[strings.ToLower(name)] = struct{}{}
+
+		if len(rule["source_addresses"].([]interface{})) == 0 && len(rule["source_ip_groups"].([]interface{})) == 0 {
+			return fmt.Errorf("rule %q in the `%s` %q must specify at least one of `source_addresses` or `source_ip_groups`", name, collection.blockName, collection.name)
+		}
+
+		if collection.blockName == "network_rule_collection" {
+			if len(rule["destination_addresses"].([]interface{})) == 0 && len(rule["destination_ip_groups"].([]interface{})) == 0 && len(rule["destination_fqdns"].([]interface{})) == 0 {
+				return fmt.Errorf("rule %q in the `%s` %q must specify at least one of `destination_addresses`, `destination_ip_groups` or `destination_fqdns`", name, collection.blockName, collection.name)
+			}
+		}
+	}
+
+	return nil
+}
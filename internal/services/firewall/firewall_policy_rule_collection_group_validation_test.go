@@ -0,0 +1,124 @@
+package firewall
+
+import (
+	"testing"
+)
+
+func TestValidateFirewallPolicyRuleCollectionsAreUnique(t *testing.T) {
+	testData := []struct {
+		Name        string
+		Collections []firewallPolicyRuleCollection
+		Error       bool
+	}{
+		{
+			Name: "Unique",
+			Collections: []firewallPolicyRuleCollection{
+				{blockName: "network_rule_collection", name: "first", priority: 100},
+				{blockName: "application_rule_collection", name: "second", priority: 200},
+			},
+			Error: false,
+		},
+		{
+			Name: "Duplicate Priority",
+			Collections: []firewallPolicyRuleCollection{
+				{blockName: "network_rule_collection", name: "first", priority: 100},
+				{blockName: "application_rule_collection", name: "second", priority: 100},
+			},
+			Error: true,
+		},
+		{
+			Name: "Duplicate Name",
+			Collections: []firewallPolicyRuleCollection{
+				{blockName: "network_rule_collection", name: "first", priority: 100},
+				{blockName: "nat_rule_collection", name: "First", priority: 200},
+			},
+			Error: true,
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q", v.Name)
+
+		err := validateFirewallPolicyRuleCollectionsAreUnique(v.Collections)
+		if v.Error != (err != nil) {
+			t.Fatalf("expected an error to be %t but got: %+v", v.Error, err)
+		}
+	}
+}
+
+func TestValidateFirewallPolicyRulesInCollection(t *testing.T) {
+	testData := []struct {
+		Name       string
+		Collection firewallPolicyRuleCollection
+		Error      bool
+	}{
+		{
+			Name: "Valid Network Rules",
+			Collection: firewallPolicyRuleCollection{
+				blockName: "network_rule_collection",
+				name:      "collection",
+				rules: []map[string]interface{}{
+					testFirewallPolicyNetworkRule("first", []interface{}{"10.0.0.0/16"}, []interface{}{"192.168.0.0/16"}, []interface{}{"443"}),
+					testFirewallPolicyNetworkRule("second", []interface{}{"10.0.0.0/16"}, []interface{}{"192.168.0.0/16"}, []interface{}{"80"}),
+				},
+			},
+			Error: false,
+		},
+		{
+			Name: "Duplicate Rule Names",
+			Collection: firewallPolicyRuleCollection{
+				blockName: "network_rule_collection",
+				name:      "collection",
+				rules: []map[string]interface{}{
+					testFirewallPolicyNetworkRule("first", []interface{}{"10.0.0.0/16"}, []interface{}{"192.168.0.0/16"}, []interface{}{"443"}),
+					testFirewallPolicyNetworkRule("first", []interface{}{"10.0.0.0/16"}, []interface{}{"192.168.0.0/16"}, []interface{}{"80"}),
+				},
+			},
+			Error: true,
+		},
+		{
+			Name: "Missing Source",
+			Collection: firewallPolicyRuleCollection{
+				blockName: "network_rule_collection",
+				name:      "collection",
+				rules: []map[string]interface{}{
+					testFirewallPolicyNetworkRule("first", []interface{}{}, []interface{}{"192.168.0.0/16"}, []interface{}{"443"}),
+				},
+			},
+			Error: true,
+		},
+		{
+			Name: "Missing Destination",
+			Collection: firewallPolicyRuleCollection{
+				blockName: "network_rule_collection",
+				name:      "collection",
+				rules: []map[string]interface{}{
+					testFirewallPolicyNetworkRule("first", []interface{}{"10.0.0.0/16"}, []interface{}{}, []interface{}{"443"}),
+				},
+			},
+			Error: true,
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q", v.Name)
+
+		err := validateFirewallPolicyRulesInCollection(v.Collection)
+		if v.Error != (err != nil) {
+			t.Fatalf("expected an error to be %t but got: %+v", v.Error, err)
+		}
+	}
+}
+
+func testFirewallPolicyNetworkRule(name string, sources, destinations, ports []interface{}) map[string]interface{} {
+	return map[string]interface{}{
+		"name":                  name,
+		"protocols":             []interface{}{"TCP"},
+		"source_addresses":      sources,
+		"source_ip_groups":      []interface{}{},
+		"destination_addresses": destinations,
+		"destination_ip_groups": []interface{}{},
+		"destination_fqdns":     []interface{}{},
+		"destination_ports":     ports,
+	}
+}
@@ -175,6 +175,8 @@ func resourceNetworkSecurityGroup() *pluginsdk.Resource {
 
 			"tags": tags.Schema(),
 		},
+
+		CustomizeDiff: pluginsdk.CustomizeDiffShim(networkSecurityGroupCustomizeDiff),
 	}
 }
 
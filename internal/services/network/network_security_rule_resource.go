@@ -166,6 +166,8 @@ func resourceNetworkSecurityRule() *pluginsdk.Resource {
 				}, false),
 			},
 		},
+
+		CustomizeDiff: pluginsdk.CustomizeDiffShim(networkSecurityRuleCustomizeDiff),
 	}
 }
 
@@ -0,0 +1,189 @@
+package network
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/utils"
+)
+
+var networkSecurityRuleAnalysisKeys = []string{
+	"name",
+	"source_port_range",
+	"source_port_ranges",
+	"destination_port_range",
+	"destination_port_ranges",
+	"source_address_prefix",
+	"source_address_prefixes",
+	"destination_address_prefix",
+	"destination_address_prefixes",
+	"source_application_security_group_ids",
+	"destination_application_security_group_ids",
+	"priority",
+	"direction",
+}
+
+type networkSecurityRuleSummary struct {
+	name                                   string
+	direction                              string
+	priority                               int
+	sourcePorts                            []string
+	destinationPorts                       []string
+	sourceAddresses                        []string
+	destinationAddresses                   []string
+	sourceApplicationSecurityGroupIds      []string
+	destinationApplicationSecurityGroupIds []string
+}
+
+// networkSecurityGroupCustomizeDiff analyses the inline Security Rules within a Network Security Group at plan time, to
+// surface conflicts which the API would otherwise reject part-way through an apply (or silently accept).
+func networkSecurityGroupCustomizeDiff(ctx context.Context, d *pluginsdk.ResourceDiff, _ interface{}) error {
+	// when `security_rule` isn't specified the rules are managed via `azurerm_network_security_rule`, which can't be
+	// evaluated here - and the values can't be evaluated until they're known, which happens during the apply
+	raw := d.GetRawConfig()
+	if raw.IsNull() || raw.GetAttr("security_rule").IsNull() || !raw.GetAttr("security_rule").IsWhollyKnown() {
+		return nil
+	}
+
+	rules := make([]networkSecurityRuleSummary, 0)
+	for _, item := range d.Get("security_rule").(*pluginsdk.Set).List() {
+		if item == nil {
+			continue
+		}
+		rules = append(rules, expandNetworkSecurityRuleSummary(item.(map[string]interface{})))
+	}
+
+	for _, rule := range rules {
+		if err := validateNetworkSecurityRuleSummary(rule); err != nil {
+			return err
+		}
+	}
+
+	return validateNetworkSecurityRuleSummaries(rules)
+}
+
+// networkSecurityRuleCustomizeDiff analyses a standalone Security Rule at plan time - since the other Security Rules within
+// the Network Security Group are managed separately, these are retrieved from the API to check the priority is unique.
+func networkSecurityRuleCustomizeDiff(ctx context.Context, d *pluginsdk.ResourceDiff, meta interface{}) error {
+	input := make(map[string]interface{})
+	for _, key := range networkSecurityRuleAnalysisKeys {
+		// the values can't be evaluated until they're known, which happens during the apply
+		if !d.NewValueKnown(key) {
+			return nil
+		}
+		input[key] = d.Get(key)
+	}
+
+	rule := expandNetworkSecurityRuleSummary(input)
+	if err := validateNetworkSecurityRuleSummary(rule); err != nil {
+		return err
+	}
+
+	if !d.NewValueKnown("network_security_group_name") || !d.NewValueKnown("resource_group_name") {
+		return nil
+	}
+	networkSecurityGroupName := d.Get("network_security_group_name").(string)
+	resourceGroup := d.Get("resource_group_name").(string)
+	if networkSecurityGroupName == "" || resourceGroup == "" {
+		return nil
+	}
+
+	client := meta.(*clients.Client).Network.SecurityGroupClient
+	resp, err := client.Get(ctx, resourceGroup, networkSecurityGroupName, "")
+	if err != nil {
+		// the Network Security Group may not have been created yet
+		if utils.ResponseWasNotFound(resp.Response) {
+			return nil
+		}
+
+		return fmt.Errorf("retrieving Network Security Group %q (Resource Group %q): %+v", networkSecurityGroupName, resourceGroup, err)
+	}
+
+	if props := resp.SecurityGroupPropertiesFormat; props != nil && props.SecurityRules != nil {
+		for _, existing := range *props.SecurityRules {
+			if existing.Name == nil || strings.EqualFold(*existing.Name, rule.name) || existing.SecurityRulePropertiesFormat == nil || existing.Priority == nil {
+				continue
+			}
+
+			if strings.EqualFold(string(existing.Direction), rule.direction) && int(*existing.Priority) == rule.priority {
+				return fmt.Errorf("the Security Rule %q within Network Security Group %q (Resource Group %q) is already an %s rule with the priority %d - priorities must be unique for each direction within a Network Security Group", *existing.Name, networkSecurityGroupName, resourceGroup, rule.direction, rule.priority)
+			}
+		}
+	}
+
+	return nil
+}
+
+func expandNetworkSecurityRuleSummary(input map[string]interface{}) networkSecurityRuleSummary {
+	combine := func(singleKey, multipleKey string) []string {
+		output := make([]string, 0)
+		if v, ok := input[singleKey].(string); ok && v != "" {
+			output = append(output, v)
+		}
+		if v, ok := input[multipleKey].(*pluginsdk.Set); ok && v != nil {
+			for _, item := range v.List() {
+				output = append(output, item.(string))
+			}
+		}
+		sort.Strings(output)
+		return output
+	}
+
+	return networkSecurityRuleSummary{
+		name:                                   input["name"].(string),
+		direction:                              input["direction"].(string),
+		priority:                               input["priority"].(int),
+		sourcePorts:                            combine("source_port_range", "source_port_ranges"),
+		destinationPorts:                       combine("destination_port_range", "destination_port_ranges"),
+		sourceAddresses:                        combine("source_address_prefix", "source_address_prefixes"),
+		destinationAddresses:                   combine("destination_address_prefix", "destination_address_prefixes"),
+		sourceApplicationSecurityGroupIds:      combine("", "source_application_security_group_ids"),
+		destinationApplicationSecurityGroupIds: combine("", "destination_application_security_group_ids"),
+	}
+}
+
+func validateNetworkSecurityRuleSummary(rule networkSecurityRuleSummary) error {
+	if len(rule.sourcePorts) == 0 {
+		return fmt.Errorf("Security Rule %q must specify either `source_port_range` or `source_port_ranges`", rule.name)
+	}
+	if len(rule.destinationPorts) == 0 {
+		return fmt.Errorf("Security Rule %q must specify either `destination_port_range` or `destination_port_ranges`", rule.name)
+	}
+	if len(rule.sourceAddresses) == 0 && len(rule.sourceApplicationSecurityGroupIds) == 0 {
+		return fmt.Errorf("Security Rule %q must specify one of `source_address_prefix`, `source_address_prefixes` or `source_application_security_group_ids`", rule.name)
+	}
+	if len(rule.destinationAddresses) == 0 && len(rule.destinationApplicationSecurityGroupIds) == 0 {
+		return fmt.Errorf("Security Rule %q must specify one of `destination_address_prefix`, `destination_address_prefixes` or `destination_application_security_group_ids`", rule.name)
+	}
+
+	return nil
+}
+
+// validateNetworkSecurityRuleSummaries checks that the Security Rules within a Network Security Group have unique names, and
+// unique priorities for each direction.
+func validateNetworkSecurityRuleSummaries(rules []networkSecurityRuleSummary) error {
+	sort.SliceStable(rules, func(i, j int) bool {
+		return rules[i].priority < rules[j].priority
+	})
+
+	names := make(map[string]struct{})
+	priorities := make(map[string]networkSecurityRuleSummary)
+	for _, rule := range rules {
+		if _, ok := names[strings.ToLower(rule.name)]; ok {
+			return fmt.Errorf("more than one Security Rule is named %q - Security Rule names must be unique within a Network Security Group", rule.name)
+		}
+		names[strings.ToLower(rule.name)] = struct{}{}
+
+		priorityKey := fmt.Sprintf("%s-%d", strings.ToLower(rule.direction), rule.priority)
+		if existing, ok := priorities[priorityKey]; ok {
+			return fmt.Errorf("the Security Rules %q and %q are both %s rules with the priority %d - priorities must be unique for each direction within a Network Security Group", existing.name, rule.name, rule.direction, rule.priority)
+		}
+		priorities[priorityKey] = rule
+	}
+
+	return nil
+}
@@ -0,0 +1,113 @@
+package network
+
+import (
+	"testing"
+)
+
+func TestValidateNetworkSecurityRuleSummary(t *testing.T) {
+	testData := []struct {
+		Name  string
+		Rule  networkSecurityRuleSummary
+		Error bool
+	}{
+		{
+			Name:  "Valid",
+			Rule:  testNetworkSecurityRuleSummary("valid", 100, []string{"10.0.0.0/16"}, []string{"443"}),
+			Error: false,
+		},
+		{
+			Name: "Missing Source Port",
+			Rule: networkSecurityRuleSummary{
+				name:                 "missing-source-port",
+				destinationPorts:     []string{"443"},
+				sourceAddresses:      []string{"*"},
+				destinationAddresses: []string{"*"},
+			},
+			Error: true,
+		},
+		{
+			Name: "Missing Destination",
+			Rule: networkSecurityRuleSummary{
+				name:             "missing-destination",
+				sourcePorts:      []string{"*"},
+				destinationPorts: []string{"443"},
+				sourceAddresses:  []string{"*"},
+			},
+			Error: true,
+		},
+		{
+			Name: "Application Security Group Destination",
+			Rule: networkSecurityRuleSummary{
+				name:                                   "asg-destination",
+				sourcePorts:                            []string{"*"},
+				destinationPorts:                       []string{"443"},
+				sourceAddresses:                        []string{"*"},
+				destinationApplicationSecurityGroupIds: []string{"/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/group1/providers/Microsoft.Network/applicationSecurityGroups/securityGroup1"},
+			},
+			Error: false,
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q", v.Name)
+
+		err := validateNetworkSecurityRuleSummary(v.Rule)
+		if v.Error != (err != nil) {
+			t.Fatalf("expected an error to be %t but got: %+v", v.Error, err)
+		}
+	}
+}
+
+func TestValidateNetworkSecurityRuleSummaries(t *testing.T) {
+	testData := []struct {
+		Name  string
+		Rules []networkSecurityRuleSummary
+		Error bool
+	}{
+		{
+			Name: "No Overlap",
+			Rules: []networkSecurityRuleSummary{
+				testNetworkSecurityRuleSummary("allow-https", 100, []string{"10.0.0.0/16"}, []string{"443"}),
+				testNetworkSecurityRuleSummary("deny-ssh", 200, []string{"10.0.0.0/16"}, []string{"22"}),
+			},
+			Error: false,
+		},
+		{
+			Name: "Duplicate Priority",
+			Rules: []networkSecurityRuleSummary{
+				testNetworkSecurityRuleSummary("allow-https", 100, []string{"10.0.0.0/16"}, []string{"443"}),
+				testNetworkSecurityRuleSummary("deny-ssh", 100, []string{"10.0.0.0/16"}, []string{"22"}),
+			},
+			Error: true,
+		},
+		{
+			Name: "Duplicate Name",
+			Rules: []networkSecurityRuleSummary{
+				testNetworkSecurityRuleSummary("rule", 100, []string{"10.0.0.0/16"}, []string{"443"}),
+				testNetworkSecurityRuleSummary("rule", 200, []string{"10.0.0.0/16"}, []string{"22"}),
+			},
+			Error: true,
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q", v.Name)
+
+		err := validateNetworkSecurityRuleSummaries(v.Rules)
+		if v.Error != (err != nil) {
+			t.Fatalf("expected an error to be %t but got: %+v", v.Error, err)
+		}
+	}
+}
+
+func testNetworkSecurityRuleSummary(name string, priority int, sourceAddresses, destinationPorts []string) networkSecurityRuleSummary {
+	return networkSecurityRuleSummary{
+		name:                 name,
+		direction:            "Inbound",
+		priority:             priority,
+		sourcePorts:          []string{"*"},
+		destinationPorts:     destinationPorts,
+		sourceAddresses:      sourceAddresses,
+		destinationAddresses: []string{"*"},
+	}
+}
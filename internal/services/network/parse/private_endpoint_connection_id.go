@@ -0,0 +1,105 @@
+package parse
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/resourceids"
+	"github.com/hashicorp/terraform-provider-azurerm/helpers/azure"
+)
+
+var _ resourceids.Id = PrivateEndpointConnectionId{}
+
+// PrivateEndpointConnectionId is the ID of a Private Endpoint Connection nested beneath any
+// Private Link-enabled resource (e.g. a Storage Account, Key Vault or Private Link Service)
+type PrivateEndpointConnectionId struct {
+	TargetResourceId string
+	Name             string
+}
+
+func NewPrivateEndpointConnectionID(targetResourceId, name string) PrivateEndpointConnectionId {
+	return PrivateEndpointConnectionId{
+		TargetResourceId: targetResourceId,
+		Name:             name,
+	}
+}
+
+func (id PrivateEndpointConnectionId) ID() string {
+	return fmt.Sprintf("%s/privateEndpointConnections/%s", id.TargetResourceId, id.Name)
+}
+
+func (id PrivateEndpointConnectionId) String() string {
+	components := []string{
+		fmt.Sprintf("Target Resource %q", id.TargetResourceId),
+		fmt.Sprintf("Name %q", id.Name),
+	}
+	return fmt.Sprintf("Private Endpoint Connection: %s", strings.Join(components, " / "))
+}
+
+// ProviderNamespaceAndResourceType returns the Resource Provider Namespace (e.g. `Microsoft.Storage`) and the
+// Resource Type of the Private Endpoint Connection (e.g. `storageAccounts/privateEndpointConnections`)
+func (id PrivateEndpointConnectionId) ProviderNamespaceAndResourceType() (string, string, error) {
+	segments := strings.Split(strings.Trim(id.TargetResourceId, "/"), "/")
+
+	providerIndex := -1
+	for i, segment := range segments {
+		if strings.EqualFold(segment, "providers") {
+			providerIndex = i
+		}
+	}
+	if providerIndex == -1 || providerIndex+1 >= len(segments) {
+		return "", "", fmt.Errorf("expected a Resource Provider to be present in %q", id.TargetResourceId)
+	}
+
+	typeSegments := segments[providerIndex+2:]
+	if len(typeSegments) == 0 || len(typeSegments)%2 != 0 {
+		return "", "", fmt.Errorf("expected the Resource Type and Name segments to be present in %q", id.TargetResourceId)
+	}
+
+	types := make([]string, 0)
+	for i := 0; i < len(typeSegments); i += 2 {
+		types = append(types, typeSegments[i])
+	}
+	types = append(types, "privateEndpointConnections")
+
+	return segments[providerIndex+1], strings.Join(types, "/"), nil
+}
+
+// PrivateEndpointConnectionID parses a Private Endpoint Connection ID into a PrivateEndpointConnectionId struct
+func PrivateEndpointConnectionID(input string) (*PrivateEndpointConnectionId, error) {
+	index := strings.LastIndex(strings.ToLower(input), "/privateendpointconnections/")
+	if index == -1 {
+		return nil, fmt.Errorf("expected the ID to be in the format `{targetResourceId}/privateEndpointConnections/{name}` but got %q", input)
+	}
+
+	targetResourceId := input[:index]
+	name := input[index+len("/privateEndpointConnections/"):]
+	if name == "" || strings.Contains(name, "/") {
+		return nil, fmt.Errorf("expected the Private Endpoint Connection Name to be the last segment of %q", input)
+	}
+
+	if _, err := azure.ParseAzureResourceID(targetResourceId); err != nil {
+		return nil, fmt.Errorf("parsing the Target Resource ID %q: %+v", targetResourceId, err)
+	}
+
+	id := NewPrivateEndpointConnectionID(targetResourceId, name)
+	if _, _, err := id.ProviderNamespaceAndResourceType(); err != nil {
+		return nil, err
+	}
+
+	return &id, nil
+}
+
+func PrivateEndpointConnectionIDValidation(input interface{}, key string) (warnings []string, errors []error) {
+	v, ok := input.(string)
+	if !ok {
+		errors = append(errors, fmt.Errorf("expected %q to be a string", key))
+		return
+	}
+
+	if _, err := PrivateEndpointConnectionID(v); err != nil {
+		errors = append(errors, err)
+	}
+
+	return
+}
@@ -0,0 +1,105 @@
+package parse
+
+import (
+	"testing"
+)
+
+func TestPrivateEndpointConnectionID(t *testing.T) {
+	testData := []struct {
+		Name         string
+		Input        string
+		Expect       *PrivateEndpointConnectionId
+		Namespace    string
+		ResourceType string
+		Error        bool
+	}{
+		{
+			Name:  "Empty",
+			Input: "",
+			Error: true,
+		},
+		{
+			Name:  "Target Resource ID only",
+			Input: "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/resGroup1/providers/Microsoft.Storage/storageAccounts/account1",
+			Error: true,
+		},
+		{
+			Name:  "Missing Connection Name",
+			Input: "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/resGroup1/providers/Microsoft.Storage/storageAccounts/account1/privateEndpointConnections/",
+			Error: true,
+		},
+		{
+			Name:  "Missing Provider",
+			Input: "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/resGroup1/privateEndpointConnections/connection1",
+			Error: true,
+		},
+		{
+			Name:  "Storage Account",
+			Input: "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/resGroup1/providers/Microsoft.Storage/storageAccounts/account1/privateEndpointConnections/connection1",
+			Expect: &PrivateEndpointConnectionId{
+				TargetResourceId: "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/resGroup1/providers/Microsoft.Storage/storageAccounts/account1",
+				Name:             "connection1",
+			},
+			Namespace:    "Microsoft.Storage",
+			ResourceType: "storageAccounts/privateEndpointConnections",
+		},
+		{
+			Name:  "Nested Resource",
+			Input: "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/resGroup1/providers/Microsoft.Web/sites/site1/slots/slot1/privateEndpointConnections/connection1",
+			Expect: &PrivateEndpointConnectionId{
+				TargetResourceId: "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/resGroup1/providers/Microsoft.Web/sites/site1/slots/slot1",
+				Name:             "connection1",
+			},
+			Namespace:    "Microsoft.Web",
+			ResourceType: "sites/slots/privateEndpointConnections",
+		},
+		{
+			Name:  "Lower-cased Segment",
+			Input: "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/resGroup1/providers/Microsoft.Network/privateLinkServices/service1/privateendpointconnections/connection1",
+			Expect: &PrivateEndpointConnectionId{
+				TargetResourceId: "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/resGroup1/providers/Microsoft.Network/privateLinkServices/service1",
+				Name:             "connection1",
+			},
+			Namespace:    "Microsoft.Network",
+			ResourceType: "privateLinkServices/privateEndpointConnections",
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q", v.Name)
+
+		actual, err := PrivateEndpointConnectionID(v.Input)
+		if err != nil {
+			if v.Error {
+				continue
+			}
+
+			t.Fatalf("Expected a value but got an error: %s", err)
+		}
+
+		if v.Error {
+			t.Fatal("Expect an error but didn't get one")
+		}
+
+		if actual.TargetResourceId != v.Expect.TargetResourceId {
+			t.Fatalf("Expected %q but got %q for TargetResourceId", v.Expect.TargetResourceId, actual.TargetResourceId)
+		}
+
+		if actual.Name != v.Expect.Name {
+			t.Fatalf("Expected %q but got %q for Name", v.Expect.Name, actual.Name)
+		}
+
+		namespace, resourceType, err := actual.ProviderNamespaceAndResourceType()
+		if err != nil {
+			t.Fatalf("Expected a Resource Type but got an error: %s", err)
+		}
+
+		if namespace != v.Namespace {
+			t.Fatalf("Expected %q but got %q for Namespace", v.Namespace, namespace)
+		}
+
+		if resourceType != v.ResourceType {
+			t.Fatalf("Expected %q but got %q for ResourceType", v.ResourceType, resourceType)
+		}
+	}
+}
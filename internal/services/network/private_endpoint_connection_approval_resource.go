@@ -0,0 +1,318 @@
+package network
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/services/resources/mgmt/2020-06-01/resources" // nolint: staticcheck
+	"github.com/hashicorp/terraform-provider-azurerm/helpers/azure"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/locks"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/network/parse"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/network/validate"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/validation"
+	"github.com/hashicorp/terraform-provider-azurerm/utils"
+)
+
+const (
+	privateEndpointConnectionStatusApproved = "Approved"
+	privateEndpointConnectionStatusRejected = "Rejected"
+)
+
+type PrivateEndpointConnectionApprovalModel struct {
+	Name              string `tfschema:"name"`
+	TargetResourceId  string `tfschema:"target_resource_id"`
+	Status            string `tfschema:"status"`
+	RequestMessage    string `tfschema:"request_message"`
+	PrivateEndpointId string `tfschema:"private_endpoint_id"`
+}
+
+type PrivateEndpointConnectionApprovalResource struct{}
+
+var _ sdk.ResourceWithUpdate = PrivateEndpointConnectionApprovalResource{}
+
+func (r PrivateEndpointConnectionApprovalResource) ResourceType() string {
+	return "azurerm_private_endpoint_connection_approval"
+}
+
+func (r PrivateEndpointConnectionApprovalResource) ModelObject() interface{} {
+	return &PrivateEndpointConnectionApprovalModel{}
+}
+
+func (r PrivateEndpointConnectionApprovalResource) IDValidationFunc() pluginsdk.SchemaValidateFunc {
+	return parse.PrivateEndpointConnectionIDValidation
+}
+
+func (r PrivateEndpointConnectionApprovalResource) Arguments() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"name": {
+			Type:         pluginsdk.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: validate.PrivateLinkName,
+		},
+
+		"target_resource_id": {
+			Type:         pluginsdk.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: azure.ValidateResourceID,
+		},
+
+		"status": {
+			Type:     pluginsdk.TypeString,
+			Optional: true,
+			Default:  privateEndpointConnectionStatusApproved,
+			ValidateFunc: validation.StringInSlice([]string{
+				privateEndpointConnectionStatusApproved,
+				privateEndpointConnectionStatusRejected,
+			}, false),
+		},
+
+		"request_message": {
+			Type:         pluginsdk.TypeString,
+			Optional:     true,
+			ValidateFunc: validation.StringLenBetween(1, 140),
+		},
+	}
+}
+
+func (r PrivateEndpointConnectionApprovalResource) Attributes() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"private_endpoint_id": {
+			Type:     pluginsdk.TypeString,
+			Computed: true,
+		},
+	}
+}
+
+func (r PrivateEndpointConnectionApprovalResource) Create() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 30 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.Resource.ResourcesClient
+			providersClient := metadata.Client.Resource.ResourceProvidersClient
+
+			var model PrivateEndpointConnectionApprovalModel
+			if err := metadata.Decode(&model); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			id := parse.NewPrivateEndpointConnectionID(model.TargetResourceId, model.Name)
+
+			locks.ByID(id.TargetResourceId)
+			defer locks.UnlockByID(id.TargetResourceId)
+
+			apiVersion, err := privateEndpointConnectionAPIVersion(ctx, providersClient, id)
+			if err != nil {
+				return err
+			}
+
+			// the Private Endpoint Connection is created by the Private Endpoint, so it must already exist
+			existing, err := client.GetByID(ctx, id.ID(), apiVersion)
+			if err != nil {
+				if utils.ResponseWasNotFound(existing.Response) {
+					return fmt.Errorf("%s was not found - the Private Endpoint must request the connection before it can be approved or rejected", id)
+				}
+				return fmt.Errorf("retrieving %s: %+v", id, err)
+			}
+
+			if err := updatePrivateEndpointConnectionState(ctx, client, id, apiVersion, existing, model.Status, model.RequestMessage); err != nil {
+				return err
+			}
+
+			metadata.SetID(id)
+			return nil
+		},
+	}
+}
+
+func (r PrivateEndpointConnectionApprovalResource) Read() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 5 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.Resource.ResourcesClient
+			providersClient := metadata.Client.Resource.ResourceProvidersClient
+
+			id, err := parse.PrivateEndpointConnectionID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			apiVersion, err := privateEndpointConnectionAPIVersion(ctx, providersClient, *id)
+			if err != nil {
+				return err
+			}
+
+			existing, err := client.GetByID(ctx, id.ID(), apiVersion)
+			if err != nil {
+				if utils.ResponseWasNotFound(existing.Response) {
+					return metadata.MarkAsGone(id)
+				}
+				return fmt.Errorf("retrieving %s: %+v", *id, err)
+			}
+
+			state := PrivateEndpointConnectionApprovalModel{
+				Name:             id.Name,
+				TargetResourceId: id.TargetResourceId,
+			}
+
+			if props, ok := existing.Properties.(map[string]interface{}); ok {
+				if connectionState, ok := props["privateLinkServiceConnectionState"].(map[string]interface{}); ok {
+					if v, ok := connectionState["status"].(string); ok {
+						state.Status = v
+					}
+					if v, ok := connectionState["description"].(string); ok {
+						state.RequestMessage = v
+					}
+				}
+
+				if privateEndpoint, ok := props["privateEndpoint"].(map[string]interface{}); ok {
+					if v, ok := privateEndpoint["id"].(string); ok {
+						state.PrivateEndpointId = v
+					}
+				}
+			}
+
+			return metadata.Encode(&state)
+		},
+	}
+}
+
+func (r PrivateEndpointConnectionApprovalResource) Update() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 30 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.Resource.ResourcesClient
+			providersClient := metadata.Client.Resource.ResourceProvidersClient
+
+			id, err := parse.PrivateEndpointConnectionID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			var model PrivateEndpointConnectionApprovalModel
+			if err := metadata.Decode(&model); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			locks.ByID(id.TargetResourceId)
+			defer locks.UnlockByID(id.TargetResourceId)
+
+			apiVersion, err := privateEndpointConnectionAPIVersion(ctx, providersClient, *id)
+			if err != nil {
+				return err
+			}
+
+			existing, err := client.GetByID(ctx, id.ID(), apiVersion)
+			if err != nil {
+				return fmt.Errorf("retrieving %s: %+v", *id, err)
+			}
+
+			return updatePrivateEndpointConnectionState(ctx, client, *id, apiVersion, existing, model.Status, model.RequestMessage)
+		},
+	}
+}
+
+func (r PrivateEndpointConnectionApprovalResource) Delete() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 5 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			id, err := parse.PrivateEndpointConnectionID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			// a Private Endpoint Connection can't be moved back into a Pending state and it's owned by the
+			// Private Endpoint, so there's nothing to do here beyond removing it from the state
+			log.Printf("[DEBUG] %s is managed by the Private Endpoint - removing from state", *id)
+			return nil
+		},
+	}
+}
+
+func updatePrivateEndpointConnectionState(ctx context.Context, client *resources.Client, id parse.PrivateEndpointConnectionId, apiVersion string, existing resources.GenericResource, status, message string) error {
+	props, ok := existing.Properties.(map[string]interface{})
+	if !ok {
+		return fmt.Errorf("retrieving %s: `properties` was nil", id)
+	}
+
+	connectionState, ok := props["privateLinkServiceConnectionState"].(map[string]interface{})
+	if !ok {
+		connectionState = make(map[string]interface{})
+	}
+	connectionState["status"] = status
+	connectionState["description"] = message
+	props["privateLinkServiceConnectionState"] = connectionState
+
+	// these are read-only and are rejected by some Resource Providers when sent back
+	delete(props, "provisioningState")
+	delete(props, "groupIds")
+
+	payload := resources.GenericResource{
+		Properties: props,
+	}
+
+	future, err := client.CreateOrUpdateByID(ctx, id.ID(), apiVersion, payload)
+	if err != nil {
+		return fmt.Errorf("setting the status of %s to %q: %+v", id, status, err)
+	}
+
+	if err := future.WaitForCompletionRef(ctx, client.Client); err != nil {
+		return fmt.Errorf("waiting for the status of %s to be set to %q: %+v", id, status, err)
+	}
+
+	return nil
+}
+
+// privateEndpointConnectionAPIVersion determines the API Version which should be used to manage the Private Endpoint
+// Connection, since this differs per Resource Provider - falling back to the API Versions of the Target Resource
+// when the Private Endpoint Connection isn't exposed as a Resource Type in its own right.
+func privateEndpointConnectionAPIVersion(ctx context.Context, client *resources.ProvidersClient, id parse.PrivateEndpointConnectionId) (string, error) {
+	namespace, resourceType, err := id.ProviderNamespaceAndResourceType()
+	if err != nil {
+		return "", err
+	}
+
+	provider, err := client.Get(ctx, namespace, "")
+	if err != nil {
+		return "", fmt.Errorf("retrieving Resource Provider %q: %+v", namespace, err)
+	}
+	if provider.ResourceTypes == nil {
+		return "", fmt.Errorf("retrieving Resource Provider %q: `resourceTypes` was nil", namespace)
+	}
+
+	targetResourceType := strings.TrimSuffix(resourceType, "/privateEndpointConnections")
+	for _, candidate := range []string{resourceType, targetResourceType} {
+		for _, item := range *provider.ResourceTypes {
+			if item.ResourceType == nil || !strings.EqualFold(*item.ResourceType, candidate) {
+				continue
+			}
+
+			if apiVersion := latestStableAPIVersion(item.APIVersions); apiVersion != "" {
+				return apiVersion, nil
+			}
+		}
+	}
+
+	return "", fmt.Errorf("unable to determine an API Version for the Resource Type %q within the Resource Provider %q", resourceType, namespace)
+}
+
+func latestStableAPIVersion(input *[]string) string {
+	if input == nil || len(*input) == 0 {
+		return ""
+	}
+
+	// the API Versions are returned in descending order
+	for _, v := range *input {
+		if !strings.Contains(strings.ToLower(v), "preview") {
+			return v
+		}
+	}
+
+	return (*input)[0]
+}
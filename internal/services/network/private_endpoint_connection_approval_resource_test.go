@@ -0,0 +1,117 @@
+package network_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance/check"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/network/parse"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/utils"
+)
+
+type PrivateEndpointConnectionApprovalResource struct{}
+
+func TestAccPrivateEndpointConnectionApproval_basic(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_private_endpoint_connection_approval", "test")
+	r := PrivateEndpointConnectionApprovalResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("status").HasValue("Approved"),
+				check.That(data.ResourceName).Key("private_endpoint_id").Exists(),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func TestAccPrivateEndpointConnectionApproval_update(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_private_endpoint_connection_approval", "test")
+	r := PrivateEndpointConnectionApprovalResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(),
+		{
+			Config: r.rejected(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("status").HasValue("Rejected"),
+				check.That(data.ResourceName).Key("request_message").HasValue("Rejected by the platform team"),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func (r PrivateEndpointConnectionApprovalResource) Exists(ctx context.Context, clients *clients.Client, state *pluginsdk.InstanceState) (*bool, error) {
+	id, err := parse.PrivateEndpointConnectionID(state.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	// the Private Endpoint Connection here is nested beneath a Private Link Service
+	serviceId, err := parse.PrivateLinkServiceID(id.TargetResourceId)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := clients.Network.PrivateLinkServiceClient.GetPrivateEndpointConnection(ctx, serviceId.ResourceGroup, serviceId.Name, id.Name, "")
+	if err != nil {
+		if utils.ResponseWasNotFound(resp.Response) {
+			return utils.Bool(false), nil
+		}
+		return nil, fmt.Errorf("retrieving %s: %+v", *id, err)
+	}
+
+	return utils.Bool(resp.PrivateEndpointConnectionProperties != nil), nil
+}
+
+func (r PrivateEndpointConnectionApprovalResource) template(data acceptance.TestData) string {
+	// the Private Endpoint Connection depends on the Private Endpoint, we deliberately introduce this dependency
+	// via reference rather than using `depends_on` since `depends_on` on a data source will make it never converge.
+	return fmt.Sprintf(`
+%s
+
+data "azurerm_private_link_service_endpoint_connections" "test" {
+  service_id          = azurerm_private_endpoint.test.private_service_connection.0.private_connection_resource_id
+  resource_group_name = azurerm_resource_group.test.name
+}
+`, PrivateEndpointResource{}.requestMessage(data, "Please approve my connection"))
+}
+
+func (r PrivateEndpointConnectionApprovalResource) basic(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_private_endpoint_connection_approval" "test" {
+  name               = data.azurerm_private_link_service_endpoint_connections.test.private_endpoint_connections.0.connection_name
+  target_resource_id = azurerm_private_link_service.test.id
+}
+`, r.template(data))
+}
+
+func (r PrivateEndpointConnectionApprovalResource) rejected(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_private_endpoint_connection_approval" "test" {
+  name               = data.azurerm_private_link_service_endpoint_connections.test.private_endpoint_connections.0.connection_name
+  target_resource_id = azurerm_private_link_service.test.id
+  status             = "Rejected"
+  request_message    = "Rejected by the platform team"
+}
+`, r.template(data))
+}
@@ -29,7 +29,10 @@ func (r Registration) WebsiteCategories() []string {
 }
 
 func (r Registration) DataSources() []sdk.DataSource {
-	return []sdk.DataSource{}
+	return []sdk.DataSource{
+		VirtualNetworkAvailableCIDRDataSource{},
+		VirtualNetworkIPAvailabilityDataSource{},
+	}
 }
 
 func (r Registration) Resources() []sdk.Resource {
@@ -42,6 +45,7 @@ func (r Registration) Resources() []sdk.Resource {
 		ManagerStaticMemberResource{},
 		ManagerSubscriptionConnectionResource{},
 		PrivateEndpointApplicationSecurityGroupAssociationResource{},
+		PrivateEndpointConnectionApprovalResource{},
 		RouteMapResource{},
 	}
 }
@@ -0,0 +1,141 @@
+package network
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/network/parse"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/network/validate"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/validation"
+	"github.com/hashicorp/terraform-provider-azurerm/utils"
+)
+
+type VirtualNetworkAvailableCIDRDataSource struct{}
+
+type VirtualNetworkAvailableCIDRDataSourceModel struct {
+	VirtualNetworkId string   `tfschema:"virtual_network_id"`
+	PrefixLength     int      `tfschema:"prefix_length"`
+	NumberOfCIDRs    int      `tfschema:"number_of_cidrs"`
+	CIDR             string   `tfschema:"cidr"`
+	CIDRs            []string `tfschema:"cidrs"`
+}
+
+var _ sdk.DataSource = VirtualNetworkAvailableCIDRDataSource{}
+
+func (r VirtualNetworkAvailableCIDRDataSource) ResourceType() string {
+	return "azurerm_virtual_network_available_cidr"
+}
+
+func (r VirtualNetworkAvailableCIDRDataSource) ModelObject() interface{} {
+	return &VirtualNetworkAvailableCIDRDataSourceModel{}
+}
+
+func (r VirtualNetworkAvailableCIDRDataSource) Arguments() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"virtual_network_id": {
+			Type:         pluginsdk.TypeString,
+			Required:     true,
+			ValidateFunc: validate.VirtualNetworkID,
+		},
+
+		"prefix_length": {
+			Type:         pluginsdk.TypeInt,
+			Required:     true,
+			ValidateFunc: validation.IntBetween(1, 128),
+		},
+
+		"number_of_cidrs": {
+			Type:         pluginsdk.TypeInt,
+			Optional:     true,
+			Default:      1,
+			ValidateFunc: validation.IntBetween(1, 256),
+		},
+	}
+}
+
+func (r VirtualNetworkAvailableCIDRDataSource) Attributes() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"cidr": {
+			Type:     pluginsdk.TypeString,
+			Computed: true,
+		},
+
+		"cidrs": {
+			Type:     pluginsdk.TypeList,
+			Computed: true,
+			Elem: &pluginsdk.Schema{
+				Type: pluginsdk.TypeString,
+			},
+		},
+	}
+}
+
+func (r VirtualNetworkAvailableCIDRDataSource) Read() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 5 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.Network.VnetClient
+
+			var state VirtualNetworkAvailableCIDRDataSourceModel
+			if err := metadata.Decode(&state); err != nil {
+				return err
+			}
+
+			id, err := parse.VirtualNetworkID(state.VirtualNetworkId)
+			if err != nil {
+				return err
+			}
+
+			resp, err := client.Get(ctx, id.ResourceGroup, id.Name, "")
+			if err != nil {
+				if utils.ResponseWasNotFound(resp.Response) {
+					return fmt.Errorf("%s was not found", id)
+				}
+				return fmt.Errorf("retrieving %s: %+v", id, err)
+			}
+
+			addressSpaces := make([]string, 0)
+			usedPrefixes := make([]string, 0)
+			if props := resp.VirtualNetworkPropertiesFormat; props != nil {
+				if space := props.AddressSpace; space != nil && space.AddressPrefixes != nil {
+					addressSpaces = *space.AddressPrefixes
+				}
+
+				if props.Subnets != nil {
+					for _, subnet := range *props.Subnets {
+						subnetProps := subnet.SubnetPropertiesFormat
+						if subnetProps == nil {
+							continue
+						}
+
+						if subnetProps.AddressPrefix != nil && *subnetProps.AddressPrefix != "" {
+							usedPrefixes = append(usedPrefixes, *subnetProps.AddressPrefix)
+						}
+						if subnetProps.AddressPrefixes != nil {
+							usedPrefixes = append(usedPrefixes, *subnetProps.AddressPrefixes...)
+						}
+					}
+				}
+			}
+
+			cidrs, err := nextAvailableCIDRs(addressSpaces, usedPrefixes, state.PrefixLength, state.NumberOfCIDRs)
+			if err != nil {
+				return fmt.Errorf("determining the available CIDRs within %s: %+v", id, err)
+			}
+			if len(cidrs) < state.NumberOfCIDRs {
+				return fmt.Errorf("%d CIDRs with a prefix length of %d were requested but only %d are available within the Address Space of %s", state.NumberOfCIDRs, state.PrefixLength, len(cidrs), id)
+			}
+
+			state.VirtualNetworkId = id.ID()
+			state.CIDR = cidrs[0]
+			state.CIDRs = cidrs
+
+			metadata.SetID(id)
+
+			return metadata.Encode(&state)
+		},
+	}
+}
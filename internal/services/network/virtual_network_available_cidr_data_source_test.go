@@ -0,0 +1,97 @@
+package network_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance/check"
+)
+
+type VirtualNetworkAvailableCIDRDataSource struct{}
+
+func TestAccDataSourceVirtualNetworkAvailableCIDR_basic(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.azurerm_virtual_network_available_cidr", "test")
+	r := VirtualNetworkAvailableCIDRDataSource{}
+
+	data.DataSourceTest(t, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("cidr").HasValue("10.0.1.0/24"),
+				check.That(data.ResourceName).Key("cidrs.#").HasValue("1"),
+			),
+		},
+	})
+}
+
+func TestAccDataSourceVirtualNetworkAvailableCIDR_multiple(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.azurerm_virtual_network_available_cidr", "test")
+	r := VirtualNetworkAvailableCIDRDataSource{}
+
+	data.DataSourceTest(t, []acceptance.TestStep{
+		{
+			Config: r.multiple(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("cidr").HasValue("10.0.0.64/26"),
+				check.That(data.ResourceName).Key("cidrs.#").HasValue("3"),
+				check.That(data.ResourceName).Key("cidrs.1").HasValue("10.0.0.128/26"),
+				check.That(data.ResourceName).Key("cidrs.2").HasValue("10.0.0.192/26"),
+			),
+		},
+	})
+}
+
+func (VirtualNetworkAvailableCIDRDataSource) basic(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+data "azurerm_virtual_network_available_cidr" "test" {
+  virtual_network_id = azurerm_virtual_network.test.id
+  prefix_length      = 24
+
+  depends_on = [azurerm_subnet.test]
+}
+`, VirtualNetworkAvailableCIDRDataSource{}.template(data))
+}
+
+func (VirtualNetworkAvailableCIDRDataSource) multiple(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+data "azurerm_virtual_network_available_cidr" "test" {
+  virtual_network_id = azurerm_virtual_network.test.id
+  prefix_length      = 26
+  number_of_cidrs    = 3
+
+  depends_on = [azurerm_subnet.test]
+}
+`, VirtualNetworkAvailableCIDRDataSource{}.template(data))
+}
+
+func (VirtualNetworkAvailableCIDRDataSource) template(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+provider "azurerm" {
+  features {}
+}
+
+resource "azurerm_resource_group" "test" {
+  name     = "acctestRG-%d"
+  location = "%s"
+}
+
+resource "azurerm_virtual_network" "test" {
+  name                = "acctestvnet-%d"
+  address_space       = ["10.0.0.0/16"]
+  location            = azurerm_resource_group.test.location
+  resource_group_name = azurerm_resource_group.test.name
+}
+
+resource "azurerm_subnet" "test" {
+  name                 = "acctestsubnet-%d"
+  resource_group_name  = azurerm_resource_group.test.name
+  virtual_network_name = azurerm_virtual_network.test.name
+  address_prefixes     = ["10.0.0.0/26"]
+}
+`, data.RandomInteger, data.Locations.Primary, data.RandomInteger, data.RandomInteger)
+}
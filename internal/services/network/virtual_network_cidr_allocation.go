@@ -0,0 +1,109 @@
+package network
+
+import (
+	"fmt"
+	"math/big"
+	"net"
+)
+
+type cidrRange struct {
+	start *big.Int
+	end   *big.Int
+	bits  int
+}
+
+// nextAvailableCIDRs returns up to `count` CIDRs with the prefix length `prefixLength` which fall within one of the
+// `addressSpaces` and don't overlap any of the `usedPrefixes` - the Address Spaces are searched in the order specified,
+// and the lowest available CIDRs within each Address Space are returned first.
+func nextAvailableCIDRs(addressSpaces []string, usedPrefixes []string, prefixLength int, count int) ([]string, error) {
+	used := make([]cidrRange, 0)
+	for _, prefix := range usedPrefixes {
+		r, _, err := parseCIDRRange(prefix)
+		if err != nil {
+			return nil, err
+		}
+		used = append(used, *r)
+	}
+
+	output := make([]string, 0)
+	for _, addressSpace := range addressSpaces {
+		space, ones, err := parseCIDRRange(addressSpace)
+		if err != nil {
+			return nil, err
+		}
+
+		// the CIDR can't be larger than the Address Space, nor can it be from the other IP family
+		if prefixLength < ones || prefixLength > space.bits {
+			continue
+		}
+
+		blockSize := new(big.Int).Lsh(big.NewInt(1), uint(space.bits-prefixLength))
+		current := new(big.Int).Set(space.start)
+		for len(output) < count {
+			end := new(big.Int).Add(current, blockSize)
+			end.Sub(end, big.NewInt(1))
+			if end.Cmp(space.end) > 0 {
+				break
+			}
+
+			// skip past the end of any overlapping prefix, aligned to the next CIDR boundary
+			var overlapEnd *big.Int
+			for _, u := range used {
+				if u.bits != space.bits || u.start.Cmp(end) > 0 || u.end.Cmp(current) < 0 {
+					continue
+				}
+				if overlapEnd == nil || u.end.Cmp(overlapEnd) > 0 {
+					overlapEnd = u.end
+				}
+			}
+			if overlapEnd != nil {
+				next := new(big.Int).Add(overlapEnd, big.NewInt(1))
+				if remainder := new(big.Int).Mod(next, blockSize); remainder.Sign() != 0 {
+					next.Add(next, blockSize)
+					next.Sub(next, remainder)
+				}
+				current = next
+				continue
+			}
+
+			output = append(output, formatCIDR(current, space.bits, prefixLength))
+			current = new(big.Int).Add(current, blockSize)
+		}
+
+		if len(output) == count {
+			break
+		}
+	}
+
+	return output, nil
+}
+
+func parseCIDRRange(input string) (*cidrRange, int, error) {
+	_, ipNet, err := net.ParseCIDR(input)
+	if err != nil {
+		return nil, 0, fmt.Errorf("parsing %q as a CIDR: %+v", input, err)
+	}
+
+	ones, bits := ipNet.Mask.Size()
+	ip := ipNet.IP.To4()
+	if bits == 128 {
+		ip = ipNet.IP.To16()
+	}
+
+	start := new(big.Int).SetBytes(ip)
+	size := new(big.Int).Lsh(big.NewInt(1), uint(bits-ones))
+	end := new(big.Int).Add(start, size)
+	end.Sub(end, big.NewInt(1))
+
+	return &cidrRange{
+		start: start,
+		end:   end,
+		bits:  bits,
+	}, ones, nil
+}
+
+func formatCIDR(start *big.Int, bits int, prefixLength int) string {
+	ip := make(net.IP, bits/8)
+	start.FillBytes(ip)
+	return fmt.Sprintf("%s/%d", ip.String(), prefixLength)
+}
@@ -0,0 +1,109 @@
+package network
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNextAvailableCIDRs(t *testing.T) {
+	testData := []struct {
+		Name          string
+		AddressSpaces []string
+		UsedPrefixes  []string
+		PrefixLength  int
+		Count         int
+		Expected      []string
+		Error         bool
+	}{
+		{
+			Name:          "Empty Virtual Network",
+			AddressSpaces: []string{"10.0.0.0/16"},
+			PrefixLength:  24,
+			Count:         2,
+			Expected:      []string{"10.0.0.0/24", "10.0.1.0/24"},
+		},
+		{
+			Name:          "Skips Existing Subnets",
+			AddressSpaces: []string{"10.0.0.0/16"},
+			UsedPrefixes:  []string{"10.0.0.0/24", "10.0.1.0/25"},
+			PrefixLength:  24,
+			Count:         1,
+			Expected:      []string{"10.0.2.0/24"},
+		},
+		{
+			Name:          "Fills Gaps Between Existing Subnets",
+			AddressSpaces: []string{"10.0.0.0/24"},
+			UsedPrefixes:  []string{"10.0.0.0/26", "10.0.0.128/26"},
+			PrefixLength:  26,
+			Count:         2,
+			Expected:      []string{"10.0.0.64/26", "10.0.0.192/26"},
+		},
+		{
+			Name:          "Aligns to the Requested Prefix Length",
+			AddressSpaces: []string{"10.0.0.0/16"},
+			UsedPrefixes:  []string{"10.0.0.0/28"},
+			PrefixLength:  24,
+			Count:         1,
+			Expected:      []string{"10.0.1.0/24"},
+		},
+		{
+			Name:          "Moves to the next Address Space",
+			AddressSpaces: []string{"10.0.0.0/24", "10.1.0.0/16"},
+			UsedPrefixes:  []string{"10.0.0.0/24"},
+			PrefixLength:  24,
+			Count:         1,
+			Expected:      []string{"10.1.0.0/24"},
+		},
+		{
+			Name:          "Prefix Larger than the Address Space",
+			AddressSpaces: []string{"10.0.0.0/24"},
+			PrefixLength:  16,
+			Count:         1,
+			Expected:      []string{},
+		},
+		{
+			Name:          "Address Space Exhausted",
+			AddressSpaces: []string{"10.0.0.0/24"},
+			UsedPrefixes:  []string{"10.0.0.0/25"},
+			PrefixLength:  25,
+			Count:         2,
+			Expected:      []string{"10.0.0.128/25"},
+		},
+		{
+			Name:          "IPv6",
+			AddressSpaces: []string{"10.0.0.0/16", "fd00:db8::/48"},
+			UsedPrefixes:  []string{"10.0.0.0/24", "fd00:db8::/64"},
+			PrefixLength:  64,
+			Count:         1,
+			Expected:      []string{"fd00:db8:0:1::/64"},
+		},
+		{
+			Name:          "Invalid Subnet Prefix",
+			AddressSpaces: []string{"10.0.0.0/16"},
+			UsedPrefixes:  []string{"10.0.0.0"},
+			PrefixLength:  24,
+			Count:         1,
+			Error:         true,
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q", v.Name)
+
+		actual, err := nextAvailableCIDRs(v.AddressSpaces, v.UsedPrefixes, v.PrefixLength, v.Count)
+		if err != nil {
+			if v.Error {
+				continue
+			}
+
+			t.Fatalf("expected no error but got: %+v", err)
+		}
+		if v.Error {
+			t.Fatalf("expected an error but didn't get one")
+		}
+
+		if !reflect.DeepEqual(v.Expected, actual) {
+			t.Fatalf("expected %+v but got %+v", v.Expected, actual)
+		}
+	}
+}
@@ -0,0 +1,111 @@
+package network
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/network/parse"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/network/validate"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/validation"
+	"github.com/hashicorp/terraform-provider-azurerm/utils"
+)
+
+type VirtualNetworkIPAvailabilityDataSource struct{}
+
+type VirtualNetworkIPAvailabilityDataSourceModel struct {
+	VirtualNetworkId     string   `tfschema:"virtual_network_id"`
+	IPAddress            string   `tfschema:"ip_address"`
+	Available            bool     `tfschema:"available"`
+	PlatformReserved     bool     `tfschema:"platform_reserved"`
+	AvailableIPAddresses []string `tfschema:"available_ip_addresses"`
+}
+
+var _ sdk.DataSource = VirtualNetworkIPAvailabilityDataSource{}
+
+func (r VirtualNetworkIPAvailabilityDataSource) ResourceType() string {
+	return "azurerm_virtual_network_ip_availability"
+}
+
+func (r VirtualNetworkIPAvailabilityDataSource) ModelObject() interface{} {
+	return &VirtualNetworkIPAvailabilityDataSourceModel{}
+}
+
+func (r VirtualNetworkIPAvailabilityDataSource) Arguments() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"virtual_network_id": {
+			Type:         pluginsdk.TypeString,
+			Required:     true,
+			ValidateFunc: validate.VirtualNetworkID,
+		},
+
+		"ip_address": {
+			Type:         pluginsdk.TypeString,
+			Required:     true,
+			ValidateFunc: validation.IsIPv4Address,
+		},
+	}
+}
+
+func (r VirtualNetworkIPAvailabilityDataSource) Attributes() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"available": {
+			Type:     pluginsdk.TypeBool,
+			Computed: true,
+		},
+
+		"platform_reserved": {
+			Type:     pluginsdk.TypeBool,
+			Computed: true,
+		},
+
+		"available_ip_addresses": {
+			Type:     pluginsdk.TypeList,
+			Computed: true,
+			Elem: &pluginsdk.Schema{
+				Type: pluginsdk.TypeString,
+			},
+		},
+	}
+}
+
+func (r VirtualNetworkIPAvailabilityDataSource) Read() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 5 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.Network.VnetClient
+
+			var state VirtualNetworkIPAvailabilityDataSourceModel
+			if err := metadata.Decode(&state); err != nil {
+				return err
+			}
+
+			id, err := parse.VirtualNetworkID(state.VirtualNetworkId)
+			if err != nil {
+				return err
+			}
+
+			result, err := client.CheckIPAddressAvailability(ctx, id.ResourceGroup, id.Name, state.IPAddress)
+			if err != nil {
+				if utils.ResponseWasNotFound(result.Response) {
+					return fmt.Errorf("%s was not found", id)
+				}
+				return fmt.Errorf("checking the availability of the IP Address %q within %s: %+v", state.IPAddress, id, err)
+			}
+
+			state.VirtualNetworkId = id.ID()
+			state.Available = utils.NormaliseNilableBool(result.Available)
+			state.PlatformReserved = utils.NormaliseNilableBool(result.IsPlatformReserved)
+			state.AvailableIPAddresses = make([]string, 0)
+			if result.AvailableIPAddresses != nil {
+				state.AvailableIPAddresses = *result.AvailableIPAddresses
+			}
+
+			metadata.SetID(id)
+
+			return metadata.Encode(&state)
+		},
+	}
+}
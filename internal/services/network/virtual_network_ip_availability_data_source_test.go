@@ -0,0 +1,72 @@
+package network_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance/check"
+)
+
+type VirtualNetworkIPAvailabilityDataSource struct{}
+
+func TestAccDataSourceVirtualNetworkIPAvailability_available(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.azurerm_virtual_network_ip_availability", "test")
+	r := VirtualNetworkIPAvailabilityDataSource{}
+
+	data.DataSourceTest(t, []acceptance.TestStep{
+		{
+			Config: r.basic(data, "10.0.1.10"),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("available").HasValue("true"),
+				check.That(data.ResourceName).Key("platform_reserved").HasValue("false"),
+			),
+		},
+	})
+}
+
+func TestAccDataSourceVirtualNetworkIPAvailability_platformReserved(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.azurerm_virtual_network_ip_availability", "test")
+	r := VirtualNetworkIPAvailabilityDataSource{}
+
+	data.DataSourceTest(t, []acceptance.TestStep{
+		{
+			Config: r.basic(data, "10.0.1.1"),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("available").HasValue("false"),
+				check.That(data.ResourceName).Key("platform_reserved").HasValue("true"),
+				check.That(data.ResourceName).Key("available_ip_addresses.#").Exists(),
+			),
+		},
+	})
+}
+
+func (VirtualNetworkIPAvailabilityDataSource) basic(data acceptance.TestData, ipAddress string) string {
+	return fmt.Sprintf(`
+provider "azurerm" {
+  features {}
+}
+
+resource "azurerm_resource_group" "test" {
+  name     = "acctestRG-%d"
+  location = "%s"
+}
+
+resource "azurerm_virtual_network" "test" {
+  name                = "acctestvnet-%d"
+  address_space       = ["10.0.0.0/16"]
+  location            = azurerm_resource_group.test.location
+  resource_group_name = azurerm_resource_group.test.name
+
+  subnet {
+    name           = "subnet1"
+    address_prefix = "10.0.1.0/24"
+  }
+}
+
+data "azurerm_virtual_network_ip_availability" "test" {
+  virtual_network_id = azurerm_virtual_network.test.id
+  ip_address         = "%s"
+}
+`, data.RandomInteger, data.Locations.Primary, data.RandomInteger, ipAddress)
+}